@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+)
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configPrintCmd)
+	configPrintCmd.Flags().String("format", "toml", "output format (toml, json)")
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "inspect gitleaks configs",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [path]",
+	Short: "report all the problems found in a config, defaults to the config gitleaks would use",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   runConfigValidate,
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print [path]",
+	Short: "print the effective config, with the config each rule and allowlist entry comes from",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   runConfigPrint,
+}
+
+func runConfigPrint(cmd *cobra.Command, args []string) {
+	cfg := resolveConfig(args)
+	selectRules(cmd, &cfg)
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	if err := cfg.Write(os.Stdout, format); err != nil {
+		log.Fatal().Err(err).Msg("could not print config")
+	}
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) {
+	vc, name := readConfig(args)
+	err := vc.Validate()
+	if err == nil {
+		log.Info().Msgf("%s is valid", name)
+		return
+	}
+
+	var errs config.Errors
+	if !errors.As(err, &errs) {
+		log.Fatal().Err(err).Msgf("unable to validate %s", name)
+	}
+	for _, e := range errs {
+		fmt.Println(e)
+	}
+	log.Error().Msgf("%d problem(s) found in %s", len(errs), name)
+	os.Exit(1)
+}
+
+// readConfig reads the config at the path in args, or the config gitleaks
+// would use if args is empty, and returns it with a name to refer to it in
+// messages.
+func readConfig(args []string) (config.ViperConfig, string) {
+	if len(args) == 1 {
+		viper.SetConfigFile(args[0])
+		if err := viper.ReadInConfig(); err != nil {
+			log.Fatal().Msgf("unable to load gitleaks config, err: %s", err)
+		}
+	} else {
+		initConfig()
+	}
+	name := viper.ConfigFileUsed()
+	if name == "" {
+		name = "default config"
+	}
+
+	var vc config.ViperConfig
+	if err := viper.Unmarshal(&vc); err != nil {
+		log.Fatal().Err(err).Msg("Failed to load config")
+	}
+	return vc, name
+}
+
+// resolveConfig loads the config at the path in args, or the config gitleaks
+// would use if args is empty, with the configs it extends.
+func resolveConfig(args []string) config.Config {
+	vc, name := readConfig(args)
+	cfg, err := vc.Translate()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load config")
+	}
+	cfg.Path = name
+	return cfg
+}
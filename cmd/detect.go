@@ -1,20 +1,31 @@
 package cmd
 
 import (
+	"errors"
+	"io/fs"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/detect"
 	"github.com/zricethezav/gitleaks/v8/report"
 	"github.com/zricethezav/gitleaks/v8/sources"
+	"github.com/zricethezav/gitleaks/v8/verify"
 )
 
 func init() {
 	rootCmd.AddCommand(detectCmd)
 	detectCmd.Flags().Bool("no-git", false, "treat git repo as a regular directory and scan those files, --log-opts has no effect on the scan when --no-git is set")
 	detectCmd.Flags().Bool("pipe", false, "scan input from stdin, ex: `cat some_file | gitleaks detect --pipe`")
+	detectCmd.Flags().String("state-file", "", "only scan the commits added since the scan which saved this file, and save the refs scanned to it, new findings are appended to the JSON report")
+	detectCmd.Flags().String("image-tar", "", "scan a container image tarball created by `docker save` or an OCI image layout directory")
+	detectCmd.Flags().Bool("verify", false, "check whether secrets are active using the [rules.verify] requests of their rules, the base url of the requests can be overridden with "+verify.BaseURLEnv)
+	detectCmd.Flags().Int("verify-concurrency", 4, "maximum number of verification requests in flight")
+	detectCmd.Flags().Float64("verify-rate-limit", 5, "maximum number of verification requests per second sent to a single host, 0 disables rate limiting")
 }
 
 var detectCmd = &cobra.Command{
@@ -43,6 +54,15 @@ func runDetect(cmd *cobra.Command, args []string) {
 	}
 	detector := Detector(cmd, cfg, source)
 
+	// verify secrets if requested
+	if verifySecrets, err := cmd.Flags().GetBool("verify"); err != nil {
+		log.Fatal().Err(err).Msg("")
+	} else if verifySecrets && !trustedConfig(cmd) {
+		log.Warn().Msg("not verifying secrets, --verify only sends the requests of the default config or of a config set by --config or GITLEAKS_CONFIG, not of the config of the source")
+	} else if verifySecrets {
+		detector.Verifier = Verifier(cmd)
+	}
+
 	// set exit code
 	exitCode, err := cmd.Flags().GetInt("exit-code")
 	if err != nil {
@@ -60,21 +80,52 @@ func runDetect(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatal().Err(err)
 	}
+	imageTar, err := cmd.Flags().GetString("image-tar")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+
+	stateFile, err := cmd.Flags().GetString("state-file")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	if stateFile != "" && (noGit || fromPipe || imageTar != "") {
+		log.Fatal().Msg("--state-file only applies to git scans")
+	}
+
+	ctx, cancel := scanContext(cmd)
+	defer cancel()
 
 	// start the detector scan
 	if noGit {
-		paths, err := sources.DirectoryTargets(source, detector.Sema, detector.FollowSymlinks)
+		addNestedConfigs(cmd, detector, source)
+		var paths <-chan sources.ScanTarget
+		paths, err = sources.DirectoryTargetsContext(ctx, source, detector.Sema, detector.FollowSymlinks)
 		if err != nil {
 			log.Fatal().Err(err)
 		}
-		findings, err = detector.DetectFiles(paths)
+		findings, err = detector.DetectFilesContext(ctx, paths)
 		if err != nil {
 			// don't exit on error, just log it
 			log.Error().Err(err).Msg("")
 		}
-	} else if fromPipe {
-		findings, err = detector.DetectReader(os.Stdin, 10)
+	} else if imageTar != "" {
+		var img *sources.Image
+		img, err = sources.NewImage(imageTar)
 		if err != nil {
+			log.Fatal().Err(err).Msg("")
+		}
+		findings, err = detector.DetectImageContext(ctx, img)
+		if err != nil {
+			// don't exit on error, just log it
+			log.Error().Err(err).Msg("")
+		}
+	} else if fromPipe {
+		findings, err = detector.DetectReaderContext(ctx, os.Stdin, 10)
+		var partialErr *detect.PartialScanError
+		if errors.As(err, &partialErr) {
+			log.Error().Err(err).Msg("")
+		} else if err != nil {
 			// log fatal to exit, no need to continue since a report
 			// will not be generated when scanning from a pipe...for now
 			log.Fatal().Err(err).Msg("")
@@ -85,16 +136,140 @@ func runDetect(cmd *cobra.Command, args []string) {
 		if err != nil {
 			log.Fatal().Err(err).Msg("")
 		}
-		gitCmd, err := sources.NewGitLogCmd(source, logOpts)
+		addNestedConfigs(cmd, detector, source)
+		var (
+			gitCmd         *sources.GitCmd
+			scanned, state sources.GitState
+		)
+		if stateFile != "" {
+			if logOpts != "" {
+				log.Fatal().Msg("--log-opts cannot be used with --state-file, which selects the commits to scan")
+			}
+			checkStateReportFormat(cmd)
+			scanned, err = sources.LoadGitState(stateFile)
+			if err != nil {
+				log.Fatal().Err(err).Msg("")
+			}
+			gitCmd, state, err = sources.NewGitLogCmdSince(source, scanned)
+		} else {
+			gitCmd, err = sources.NewGitLogCmd(source, logOpts)
+		}
 		if err != nil {
 			log.Fatal().Err(err).Msg("")
 		}
-		findings, err = detector.DetectGit(gitCmd)
+		findings, err = detector.DetectGitContext(ctx, gitCmd)
 		if err != nil {
 			// don't exit on error, just log it
 			log.Error().Err(err).Msg("")
 		}
+
+		if stateFile != "" {
+			if code := finishStateScan(cmd, cfg, findings, scanned, state, stateFile, exitCode, start, err); code != 0 {
+				os.Exit(code)
+			}
+			return
+		}
+		// only a complete scan of the full history sees every finding the
+		// entries may ignore
+		if logOpts == "" && err == nil {
+			warnUnusedIgnores(detector)
+		}
 	}
 
 	findingSummaryAndExit(findings, cmd, cfg, exitCode, start, err)
 }
+
+// finishStateScan writes the report of a --state-file scan, with the
+// findings of the previous report, saves the state once the scan completed and
+// returns the exit code. Only the findings of the commits scanned are
+// summarized and set the exit code, the previous scans reported the others.
+func finishStateScan(cmd *cobra.Command, cfg config.Config, findings []report.Finding,
+	scanned, state sources.GitState, stateFile string, exitCode int, start time.Time, err error) int {
+	logSummary(findings, start, err)
+	reported := findings
+	if reportPath, _ := cmd.Flags().GetString("report-path"); reportPath != "" && len(scanned.Refs) > 0 {
+		reported = appendFindings(previousReport(reportPath), findings)
+	}
+	writeReport(reported, cmd, cfg, err)
+
+	// the state is only saved once the report has all the findings of the
+	// commits it excludes from the next scan
+	if err == nil {
+		if err := state.Save(stateFile); err != nil {
+			log.Fatal().Err(err).Msg("could not save the state")
+		}
+	} else {
+		log.Warn().Msgf("%s is not updated, the next scan scans the same commits again", stateFile)
+	}
+	return scanExitCode(findings, cmd, exitCode, err)
+}
+
+// checkStateReportFormat exits if the report of a --state-file scan cannot
+// have findings appended to it.
+func checkStateReportFormat(cmd *cobra.Command) {
+	reportPath, _ := cmd.Flags().GetString("report-path")
+	ext, _ := cmd.Flags().GetString("report-format")
+	switch strings.ToLower(ext) {
+	case "json", ".json":
+	default:
+		if reportPath != "" {
+			log.Fatal().Msgf("--state-file appends new findings to the previous report, which must be json, not %s", ext)
+		}
+	}
+}
+
+// previousReport returns the findings of the report written at reportPath
+// by the last --state-file scan.
+func previousReport(reportPath string) []report.Finding {
+	if _, err := os.Stat(reportPath); errors.Is(err, fs.ErrNotExist) {
+		log.Warn().Msgf("the previous report %s does not exist, it only has the new findings", reportPath)
+		return nil
+	}
+	findings, err := detect.LoadBaseline(reportPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read the previous report")
+	}
+	return findings
+}
+
+// appendFindings appends the findings which previous does not have to it.
+// Commits scanned again, ex: after a scan whose state was not saved, do not
+// duplicate their findings.
+func appendFindings(previous []report.Finding, findings []report.Finding) []report.Finding {
+	seen := make(map[string]bool, len(previous))
+	for _, f := range previous {
+		seen[f.Fingerprint] = true
+	}
+	added := 0
+	for _, f := range findings {
+		if seen[f.Fingerprint] {
+			continue
+		}
+		seen[f.Fingerprint] = true
+		previous = append(previous, f)
+		added++
+	}
+	log.Info().Msgf("%d new finding(s) appended to the %d finding(s) of the previous report", added, len(previous)-added)
+	return previous
+}
+
+// Verifier returns a verifier configured with the verify flags of cmd.
+func Verifier(cmd *cobra.Command) *verify.Verifier {
+	concurrency, err := cmd.Flags().GetInt("verify-concurrency")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	rateLimit, err := cmd.Flags().GetFloat64("verify-rate-limit")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	verifier, err := verify.New(verify.Options{
+		Concurrency: concurrency,
+		RateLimit:   rateLimit,
+		BaseURL:     os.Getenv(verify.BaseURLEnv),
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	return verifier
+}
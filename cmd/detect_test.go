@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/detect"
+	"github.com/zricethezav/gitleaks/v8/report"
+	"github.com/zricethezav/gitleaks/v8/sources"
+)
+
+func TestFinishStateScan(t *testing.T) {
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "report.json")
+	statePath := filepath.Join(dir, "state.json")
+	cmd := &cobra.Command{}
+	cmd.Flags().String("report-path", reportPath, "")
+	cmd.Flags().String("report-format", "json", "")
+	cmd.Flags().String("fail-on", "", "")
+
+	old := report.Finding{RuleID: "aws-access-key", File: "old.go", StartLine: 1, Fingerprint: "old.go:aws-access-key:1"}
+	require.NoError(t, report.Write([]report.Finding{old}, config.Config{}, "json", reportPath))
+	scanned := sources.GitState{Refs: map[string]string{"refs/heads/main": "a"}}
+	state := sources.GitState{Refs: map[string]string{"refs/heads/main": "b"}}
+
+	// the findings of the previous report stay in the report but do not
+	// fail the scan
+	assert.Zero(t, finishStateScan(cmd, config.Config{}, nil, scanned, state, statePath, 1, time.Now(), nil))
+	findings, err := detect.LoadBaseline(reportPath)
+	require.NoError(t, err)
+	assert.Equal(t, []report.Finding{old}, findings)
+	saved, err := sources.LoadGitState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, state, saved)
+
+	added := report.Finding{RuleID: "aws-access-key", File: "new.go", StartLine: 2, Fingerprint: "new.go:aws-access-key:2"}
+	assert.Equal(t, 1, finishStateScan(cmd, config.Config{}, []report.Finding{added}, state, state, statePath, 1, time.Now(), nil))
+	findings, err = detect.LoadBaseline(reportPath)
+	require.NoError(t, err)
+	assert.Equal(t, []report.Finding{old, added}, findings)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/detect"
+)
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+	explainCmd.Flags().String("file", "", "file to explain, its path is matched against the path allowlists and rules as given")
+	explainCmd.Flags().Int("line", 0, "only explain the matches on this line, counting from 1")
+	explainCmd.Flags().Bool("pipe", false, "explain input from stdin, ex: `echo 'key = abc123' | gitleaks explain --pipe`")
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "show why each rule did or did not report a finding in some content",
+	Args:  cobra.NoArgs,
+	Run:   runExplain,
+}
+
+func runExplain(cmd *cobra.Command, args []string) {
+	initConfig()
+	cfg := Config(cmd)
+	source, err := cmd.Flags().GetString("source")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	detector := Detector(cmd, cfg, source)
+
+	path, err := cmd.Flags().GetString("file")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	line, err := cmd.Flags().GetInt("line")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	fromPipe, err := cmd.Flags().GetBool("pipe")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+
+	var content []byte
+	switch {
+	case fromPipe && path != "":
+		log.Fatal().Msg("only one of --file and --pipe can be set")
+	case fromPipe:
+		content, err = io.ReadAll(os.Stdin)
+	case path != "":
+		content, err = os.ReadFile(path)
+	default:
+		log.Fatal().Msg("one of --file and --pipe must be set")
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read content to explain")
+	}
+	if lines := lineCount(content); line < 0 || line > lines {
+		log.Fatal().Msgf("--line %d is out of range, the content to explain has %d line(s)", line, lines)
+	}
+
+	if path != "" {
+		addNestedConfigs(cmd, detector, source)
+	}
+	decisions := detector.Explain(detect.Fragment{
+		Raw:      string(content),
+		FilePath: path,
+	}, line)
+
+	configName := viper.ConfigFileUsed()
+	if configName == "" {
+		configName = config.DefaultSource
+	}
+	if configPath := detector.ConfigPath(path); configPath != detector.Config.Path {
+		configName = configPath
+	}
+	fmt.Printf("Config: %s\n\n", configName)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RULE\tSTAGE\tLINE\tSECRET\tREASON")
+	for _, decision := range decisions {
+		var findingLine, secret string
+		if f := decision.Finding; f != nil {
+			findingLine = strconv.Itoa(f.StartLine)
+			secret = f.Secret
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", decision.RuleID, decision.Stage, findingLine, secret, decision.Reason)
+	}
+	_ = w.Flush()
+}
+
+// lineCount returns the number of lines of content, a last line without a
+// trailing newline included.
+func lineCount(content []byte) int {
+	lines := strings.Count(string(content), "\n")
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineCount(t *testing.T) {
+	assert.Equal(t, 0, lineCount(nil))
+	assert.Equal(t, 1, lineCount([]byte("a")))
+	assert.Equal(t, 1, lineCount([]byte("a\n")))
+	assert.Equal(t, 2, lineCount([]byte("a\nb")))
+	assert.Equal(t, 2, lineCount([]byte("a\n\n")))
+}
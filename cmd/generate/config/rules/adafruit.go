@@ -12,6 +12,9 @@ func AdafruitAPIKey() *config.Rule {
 		RuleID:      "adafruit-api-key",
 		Regex:       generateSemiGenericRegex([]string{"adafruit"}, alphaNumericExtendedShort("32"), true),
 		Keywords:    []string{"adafruit"},
+		Tags:        []string{"adafruit"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,6 +12,9 @@ func AdobeClientID() *config.Rule {
 		RuleID:      "adobe-client-id",
 		Regex:       generateSemiGenericRegex([]string{"adobe"}, hex("32"), true),
 		Keywords:    []string{"adobe"},
+		Tags:        []string{"adobe"},
+		Severity:    config.SeverityLow,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -28,6 +31,9 @@ func AdobeClientSecret() *config.Rule {
 		RuleID:      "adobe-client-secret",
 		Regex:       generateUniqueTokenRegex(`(p8e-)(?i)[a-z0-9]{32}`, true),
 		Keywords:    []string{"p8e-"},
+		Tags:        []string{"adobe"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
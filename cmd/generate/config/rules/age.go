@@ -13,6 +13,9 @@ func AgeSecretKey() *config.Rule {
 		RuleID:      "age-secret-key",
 		Regex:       regexp.MustCompile(`AGE-SECRET-KEY-1[QPZRY9X8GF2TVDW0S3JN54KHCE6MUA7L]{58}`),
 		Keywords:    []string{"AGE-SECRET-KEY-1"},
+		Tags:        []string{"age", "private-key"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
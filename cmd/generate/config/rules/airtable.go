@@ -12,6 +12,9 @@ func Airtable() *config.Rule {
 		RuleID:      "airtable-api-key",
 		Regex:       generateSemiGenericRegex([]string{"airtable"}, alphaNumeric("17"), true),
 		Keywords:    []string{"airtable"},
+		Tags:        []string{"airtable"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
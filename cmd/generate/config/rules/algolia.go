@@ -12,6 +12,9 @@ func AlgoliaApiKey() *config.Rule {
 		RuleID:      "algolia-api-key",
 		Regex:       generateSemiGenericRegex([]string{"algolia"}, `[a-z0-9]{32}`, true),
 		Keywords:    []string{"algolia"},
+		Tags:        []string{"algolia"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,6 +12,9 @@ func AlibabaAccessKey() *config.Rule {
 		RuleID:      "alibaba-access-key-id",
 		Regex:       generateUniqueTokenRegex(`(LTAI)(?i)[a-z0-9]{20}`, true),
 		Keywords:    []string{"LTAI"},
+		Tags:        []string{"alibaba", "cloud"},
+		Severity:    config.SeverityLow,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -30,7 +33,10 @@ func AlibabaSecretKey() *config.Rule {
 		Regex: generateSemiGenericRegex([]string{"alibaba"},
 			alphaNumeric("30"), true),
 
-		Keywords: []string{"alibaba"},
+		Keywords:   []string{"alibaba"},
+		Tags:       []string{"alibaba", "cloud"},
+		Severity:   config.SeverityCritical,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,6 +12,9 @@ func AsanaClientID() *config.Rule {
 		RuleID:      "asana-client-id",
 		Regex:       generateSemiGenericRegex([]string{"asana"}, numeric("16"), true),
 		Keywords:    []string{"asana"},
+		Tags:        []string{"asana"},
+		Severity:    config.SeverityLow,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -28,7 +31,10 @@ func AsanaClientSecret() *config.Rule {
 		RuleID:      "asana-client-secret",
 		Regex:       generateSemiGenericRegex([]string{"asana"}, alphaNumeric("32"), true),
 
-		Keywords: []string{"asana"},
+		Keywords:   []string{"asana"},
+		Tags:       []string{"asana"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
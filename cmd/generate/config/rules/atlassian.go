@@ -12,7 +12,10 @@ func Atlassian() *config.Rule {
 		RuleID:      "atlassian-api-token",
 		Regex: generateSemiGenericRegex([]string{
 			"atlassian", "confluence", "jira"}, alphaNumeric("24"), true),
-		Keywords: []string{"atlassian", "confluence", "jira"},
+		Keywords:   []string{"atlassian", "confluence", "jira"},
+		Tags:       []string{"atlassian"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func Authress() *config.Rule {
 		RuleID:      "authress-service-client-access-key",
 		Regex:       generateUniqueTokenRegex(`(?:sc|ext|scauth|authress)_[a-z0-9]{5,30}\.[a-z0-9]{4,6}\.acc[_-][a-z0-9-]{10,32}\.[a-z0-9+/_=-]{30,120}`, true),
 		Keywords:    []string{"sc_", "ext_", "scauth_", "authress_"},
+		Tags:        []string{"authress"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -19,6 +19,9 @@ func AWS() *config.Rule {
 			"ABIA",
 			"ACCA",
 		},
+		Tags:       []string{"aws", "cloud"},
+		Severity:   config.SeverityCritical,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -12,7 +12,10 @@ func Beamer() *config.Rule {
 		RuleID:      "beamer-api-token",
 		Regex: generateSemiGenericRegex([]string{"beamer"},
 			`b_[a-z0-9=_\-]{44}`, true),
-		Keywords: []string{"beamer"},
+		Keywords:   []string{"beamer"},
+		Tags:       []string{"beamer"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,6 +12,9 @@ func BitBucketClientID() *config.Rule {
 		RuleID:      "bitbucket-client-id",
 		Regex:       generateSemiGenericRegex([]string{"bitbucket"}, alphaNumeric("32"), true),
 		Keywords:    []string{"bitbucket"},
+		Tags:        []string{"bitbucket", "vcs"},
+		Severity:    config.SeverityLow,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -28,7 +31,10 @@ func BitBucketClientSecret() *config.Rule {
 		RuleID:      "bitbucket-client-secret",
 		Regex:       generateSemiGenericRegex([]string{"bitbucket"}, alphaNumericExtended("64"), true),
 
-		Keywords: []string{"bitbucket"},
+		Keywords:   []string{"bitbucket"},
+		Tags:       []string{"bitbucket", "vcs"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,6 +12,9 @@ func BittrexAccessKey() *config.Rule {
 		RuleID:      "bittrex-access-key",
 		Regex:       generateSemiGenericRegex([]string{"bittrex"}, alphaNumeric("32"), true),
 		Keywords:    []string{"bittrex"},
+		Tags:        []string{"bittrex", "payment"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -28,7 +31,10 @@ func BittrexSecretKey() *config.Rule {
 		RuleID:      "bittrex-secret-key",
 		Regex:       generateSemiGenericRegex([]string{"bittrex"}, alphaNumeric("32"), true),
 
-		Keywords: []string{"bittrex"},
+		Keywords:   []string{"bittrex"},
+		Tags:       []string{"bittrex", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
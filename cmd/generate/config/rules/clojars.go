@@ -14,6 +14,9 @@ func Clojars() *config.Rule {
 		RuleID:      "clojars-api-token",
 		Regex:       regexp.MustCompile(`(?i)(CLOJARS_)[a-z0-9]{60}`),
 		Keywords:    []string{"clojars"},
+		Tags:        []string{"clojars"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
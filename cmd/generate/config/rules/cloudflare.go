@@ -30,7 +30,10 @@ func CloudflareGlobalAPIKey() *config.Rule {
 		RuleID:      "cloudflare-global-api-key",
 		Regex:       generateSemiGenericRegex(identifiers, hex("37"), true),
 
-		Keywords: identifiers,
+		Keywords:   identifiers,
+		Tags:       []string{"cloudflare", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -47,7 +50,10 @@ func CloudflareAPIKey() *config.Rule {
 		RuleID:      "cloudflare-api-key",
 		Regex:       generateSemiGenericRegex(identifiers, alphaNumericExtendedShort("40"), true),
 
-		Keywords: identifiers,
+		Keywords:   identifiers,
+		Tags:       []string{"cloudflare", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -58,14 +64,16 @@ func CloudflareAPIKey() *config.Rule {
 }
 
 func CloudflareOriginCAKey() *config.Rule {
-	ca_identifiers := append(identifiers, "v1.0-")
 	// define rule
 	r := config.Rule{
 		Description: "Detected a Cloudflare Origin CA Key, potentially compromising cloud application deployments and operational security.",
 		RuleID:      "cloudflare-origin-ca-key",
 		Regex:       generateUniqueTokenRegex(`v1\.0-`+hex("24")+"-"+hex("146"), false),
 
-		Keywords: ca_identifiers,
+		Keywords:   []string{"v1.0-"},
+		Tags:       []string{"cloudflare", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -14,6 +14,9 @@ func CodecovAccessToken() *config.Rule {
 		Keywords: []string{
 			"codecov",
 		},
+		Tags:       []string{"codecov"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func CoinbaseAccessToken() *config.Rule {
 		Keywords: []string{
 			"coinbase",
 		},
+		Tags:       []string{"coinbase", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
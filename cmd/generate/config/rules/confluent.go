@@ -14,6 +14,9 @@ func ConfluentSecretKey() *config.Rule {
 		Keywords: []string{
 			"confluent",
 		},
+		Tags:       []string{"confluent", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -33,6 +36,9 @@ func ConfluentAccessToken() *config.Rule {
 		Keywords: []string{
 			"confluent",
 		},
+		Tags:       []string{"confluent", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
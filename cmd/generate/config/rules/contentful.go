@@ -12,7 +12,10 @@ func Contentful() *config.Rule {
 		RuleID:      "contentful-delivery-api-token",
 		Regex: generateSemiGenericRegex([]string{"contentful"},
 			alphaNumericExtended("43"), true),
-		Keywords: []string{"contentful"},
+		Keywords:   []string{"contentful"},
+		Tags:       []string{"contentful"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,6 +12,9 @@ func Databricks() *config.Rule {
 		RuleID:      "databricks-api-token",
 		Regex:       generateUniqueTokenRegex(`dapi[a-h0-9]{32}`, true),
 		Keywords:    []string{"dapi"},
+		Tags:        []string{"databricks", "cloud"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
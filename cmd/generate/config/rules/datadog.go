@@ -15,6 +15,9 @@ func DatadogtokenAccessToken() *config.Rule {
 		Keywords: []string{
 			"datadog",
 		},
+		Tags:       []string{"datadog"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
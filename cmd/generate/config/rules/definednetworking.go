@@ -18,7 +18,10 @@ func DefinedNetworkingAPIToken() *config.Rule {
 		Regex: generateSemiGenericRegex([]string{"dnkey"}, `dnkey-[a-z0-9=_\-]{26}-[a-z0-9=_\-]{52}`, true),
 
 		// Keywords used for string matching on fragments (think of this as a prefilter)
-		Keywords: []string{"dnkey"},
+		Keywords:   []string{"dnkey"},
+		Tags:       []string{"defined-networking"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -11,6 +11,9 @@ func DigitalOceanPAT() *config.Rule {
 		RuleID:      "digitalocean-pat",
 		Regex:       generateUniqueTokenRegex(`dop_v1_[a-f0-9]{64}`, true),
 		Keywords:    []string{"dop_v1_"},
+		Tags:        []string{"digitalocean", "cloud"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	tps := []string{
@@ -24,8 +27,11 @@ func DigitalOceanOAuthToken() *config.Rule {
 		Description: "Found a DigitalOcean OAuth Access Token, risking unauthorized cloud resource access and data compromise.",
 		RuleID:      "digitalocean-access-token",
 
-		Regex:    generateUniqueTokenRegex(`doo_v1_[a-f0-9]{64}`, true),
-		Keywords: []string{"doo_v1_"},
+		Regex:      generateUniqueTokenRegex(`doo_v1_[a-f0-9]{64}`, true),
+		Keywords:   []string{"doo_v1_"},
+		Tags:       []string{"digitalocean", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	tps := []string{
@@ -39,8 +45,11 @@ func DigitalOceanRefreshToken() *config.Rule {
 		Description: "Uncovered a DigitalOcean OAuth Refresh Token, which could allow prolonged unauthorized access and resource manipulation.",
 		RuleID:      "digitalocean-refresh-token",
 
-		Regex:    generateUniqueTokenRegex(`dor_v1_[a-f0-9]{64}`, true),
-		Keywords: []string{"dor_v1_"},
+		Regex:      generateUniqueTokenRegex(`dor_v1_[a-f0-9]{64}`, true),
+		Keywords:   []string{"dor_v1_"},
+		Tags:       []string{"digitalocean", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	tps := []string{
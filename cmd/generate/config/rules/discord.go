@@ -12,6 +12,9 @@ func DiscordAPIToken() *config.Rule {
 		RuleID:      "discord-api-token",
 		Regex:       generateSemiGenericRegex([]string{"discord"}, hex("64"), true),
 		Keywords:    []string{"discord"},
+		Tags:        []string{"discord"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -28,6 +31,9 @@ func DiscordClientID() *config.Rule {
 		RuleID:      "discord-client-id",
 		Regex:       generateSemiGenericRegex([]string{"discord"}, numeric("18"), true),
 		Keywords:    []string{"discord"},
+		Tags:        []string{"discord"},
+		Severity:    config.SeverityLow,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -44,6 +50,9 @@ func DiscordClientSecret() *config.Rule {
 		RuleID:      "discord-client-secret",
 		Regex:       generateSemiGenericRegex([]string{"discord"}, alphaNumericExtended("32"), true),
 		Keywords:    []string{"discord"},
+		Tags:        []string{"discord"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -14,6 +14,9 @@ func Doppler() *config.Rule {
 		RuleID:      "doppler-api-token",
 		Regex:       regexp.MustCompile(`(dp\.pt\.)(?i)[a-z0-9]{43}`),
 		Keywords:    []string{"doppler"},
+		Tags:        []string{"doppler"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func DroneciAccessToken() *config.Rule {
 		Keywords: []string{
 			"droneci",
 		},
+		Tags:       []string{"drone-ci"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,7 +12,10 @@ func DropBoxAPISecret() *config.Rule {
 		RuleID:      "dropbox-api-token",
 		Regex:       generateSemiGenericRegex([]string{"dropbox"}, alphaNumeric("15"), true),
 
-		Keywords: []string{"dropbox"},
+		Keywords:   []string{"dropbox"},
+		Tags:       []string{"dropbox"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -29,6 +32,9 @@ func DropBoxShortLivedAPIToken() *config.Rule {
 		Description: "Discovered a Dropbox short-lived API token, posing a risk of temporary but potentially harmful data access and manipulation.",
 		Regex:       generateSemiGenericRegex([]string{"dropbox"}, `sl\.[a-z0-9\-=_]{135}`, true),
 		Keywords:    []string{"dropbox"},
+		Tags:        []string{"dropbox"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate TODO
@@ -42,6 +48,9 @@ func DropBoxLongLivedAPIToken() *config.Rule {
 		Description: "Found a Dropbox long-lived API token, risking prolonged unauthorized access to cloud storage and sensitive data.",
 		Regex:       generateSemiGenericRegex([]string{"dropbox"}, `[a-z0-9]{11}(AAAAAAAAAA)[a-z0-9\-_=]{43}`, true),
 		Keywords:    []string{"dropbox"},
+		Tags:        []string{"dropbox"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate TODO
@@ -14,6 +14,9 @@ func Duffel() *config.Rule {
 		Description: "Uncovered a Duffel API token, which may compromise travel platform integrations and sensitive customer data.",
 		Regex:       regexp.MustCompile(`duffel_(test|live)_(?i)[a-z0-9_\-=]{43}`),
 		Keywords:    []string{"duffel"},
+		Tags:        []string{"duffel"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
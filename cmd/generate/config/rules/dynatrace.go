@@ -14,6 +14,9 @@ func Dynatrace() *config.Rule {
 		RuleID:      "dynatrace-api-token",
 		Regex:       regexp.MustCompile(`dt0c01\.(?i)[a-z0-9]{24}\.[a-z0-9]{64}`),
 		Keywords:    []string{"dynatrace"},
+		Tags:        []string{"dynatrace"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
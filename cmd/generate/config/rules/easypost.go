@@ -14,6 +14,9 @@ func EasyPost() *config.Rule {
 		RuleID:      "easypost-api-token",
 		Regex:       regexp.MustCompile(`\bEZAK(?i)[a-z0-9]{54}`),
 		Keywords:    []string{"EZAK"},
+		Tags:        []string{"easypost"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -30,6 +33,9 @@ func EasyPostTestAPI() *config.Rule {
 		RuleID:      "easypost-test-api-token",
 		Regex:       regexp.MustCompile(`\bEZTK(?i)[a-z0-9]{54}`),
 		Keywords:    []string{"EZTK"},
+		Tags:        []string{"easypost"},
+		Severity:    config.SeverityLow,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func EtsyAccessToken() *config.Rule {
 		Keywords: []string{
 			"etsy",
 		},
+		Tags:       []string{"etsy"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
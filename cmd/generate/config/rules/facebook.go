@@ -14,7 +14,10 @@ func FacebookSecret() *config.Rule {
 		RuleID:      "facebook-secret",
 		Regex:       generateSemiGenericRegex([]string{"facebook"}, hex("32"), true),
 
-		Keywords: []string{"facebook"},
+		Keywords:   []string{"facebook"},
+		Tags:       []string{"facebook"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -33,6 +36,9 @@ func FacebookAccessToken() *config.Rule {
 		Description: "Discovered a Facebook Access Token, posing a risk of unauthorized access to Facebook accounts and personal data exposure.",
 		RuleID:      "facebook-access-token",
 		Regex:       generateUniqueTokenRegex(`\d{15,16}(\||%)[0-9a-z\-_]{27,40}`, true),
+		Tags:        []string{"facebook"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -52,6 +58,9 @@ func FacebookPageAccessToken() *config.Rule {
 		RuleID:      "facebook-page-access-token",
 		Regex:       generateUniqueTokenRegex("EAA[MC]"+alphaNumeric("20,"), true),
 		Keywords:    []string{"EAAM", "EAAC"},
+		Tags:        []string{"facebook"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
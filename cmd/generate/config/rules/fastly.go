@@ -12,7 +12,10 @@ func FastlyAPIToken() *config.Rule {
 		RuleID:      "fastly-api-token",
 		Regex:       generateSemiGenericRegex([]string{"fastly"}, alphaNumericExtended("32"), true),
 
-		Keywords: []string{"fastly"},
+		Keywords:   []string{"fastly"},
+		Tags:       []string{"fastly", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
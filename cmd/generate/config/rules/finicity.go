@@ -12,7 +12,10 @@ func FinicityClientSecret() *config.Rule {
 		RuleID:      "finicity-client-secret",
 		Regex:       generateSemiGenericRegex([]string{"finicity"}, alphaNumeric("20"), true),
 
-		Keywords: []string{"finicity"},
+		Keywords:   []string{"finicity"},
+		Tags:       []string{"finicity", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -29,7 +32,10 @@ func FinicityAPIToken() *config.Rule {
 		RuleID:      "finicity-api-token",
 		Regex:       generateSemiGenericRegex([]string{"finicity"}, hex("32"), true),
 
-		Keywords: []string{"finicity"},
+		Keywords:   []string{"finicity"},
+		Tags:       []string{"finicity", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
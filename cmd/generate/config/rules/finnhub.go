@@ -15,6 +15,9 @@ func FinnhubAccessToken() *config.Rule {
 		Keywords: []string{
 			"finnhub",
 		},
+		Tags:       []string{"finnhub"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
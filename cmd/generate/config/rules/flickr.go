@@ -15,6 +15,9 @@ func FlickrAccessToken() *config.Rule {
 		Keywords: []string{
 			"flickr",
 		},
+		Tags:       []string{"flickr"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
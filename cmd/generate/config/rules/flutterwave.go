@@ -14,6 +14,9 @@ func FlutterwavePublicKey() *config.Rule {
 		RuleID:      "flutterwave-public-key",
 		Regex:       regexp.MustCompile(`FLWPUBK_TEST-(?i)[a-h0-9]{32}-X`),
 		Keywords:    []string{"FLWPUBK_TEST"},
+		Tags:        []string{"flutterwave", "payment"},
+		Severity:    config.SeverityLow,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -30,6 +33,9 @@ func FlutterwaveSecretKey() *config.Rule {
 		RuleID:      "flutterwave-secret-key",
 		Regex:       regexp.MustCompile(`FLWSECK_TEST-(?i)[a-h0-9]{32}-X`),
 		Keywords:    []string{"FLWSECK_TEST"},
+		Tags:        []string{"flutterwave", "payment"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -46,6 +52,9 @@ func FlutterwaveEncKey() *config.Rule {
 		RuleID:      "flutterwave-encryption-key",
 		Regex:       regexp.MustCompile(`FLWSECK_TEST-(?i)[a-h0-9]{12}`),
 		Keywords:    []string{"FLWSECK_TEST"},
+		Tags:        []string{"flutterwave", "payment"},
+		Severity:    config.SeverityMedium,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
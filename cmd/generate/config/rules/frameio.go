@@ -14,6 +14,9 @@ func FrameIO() *config.Rule {
 		RuleID:      "frameio-api-token",
 		Regex:       regexp.MustCompile(`fio-u-(?i)[a-z0-9\-_=]{64}`),
 		Keywords:    []string{"fio-u-"},
+		Tags:        []string{"frameio"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func FreshbooksAccessToken() *config.Rule {
 		Keywords: []string{
 			"freshbooks",
 		},
+		Tags:       []string{"freshbooks"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
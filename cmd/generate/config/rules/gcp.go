@@ -15,6 +15,9 @@ func GCPServiceAccount() *config.Rule {
 		RuleID:      "gcp-service-account",
 		Regex:       regexp.MustCompile(`\"type\": \"service_account\"`),
 		Keywords:    []string{`\"type\": \"service_account\"`},
+		Tags:        []string{"gcp", "cloud"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -34,6 +37,9 @@ func GCPAPIKey() *config.Rule {
 		Keywords: []string{
 			"AIza",
 		},
+		Tags:       []string{"gcp", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
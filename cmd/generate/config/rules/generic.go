@@ -35,6 +35,8 @@ func GenericCredential() *config.Rule {
 		Allowlist: config.Allowlist{
 			StopWords: DefaultStopWords,
 		},
+		Severity:   config.SeverityMedium,
+		Confidence: config.ConfidenceLow,
 	}
 
 	// validate
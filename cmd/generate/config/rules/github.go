@@ -14,13 +14,21 @@ func GitHubPat() *config.Rule {
 		RuleID:      "github-pat",
 		Regex:       regexp.MustCompile(`ghp_[0-9a-zA-Z]{36}`),
 		Keywords:    []string{"ghp_"},
+		Validator:   validator("crc32-base62"),
+		Tags:        []string{"github", "vcs"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
 	tps := []string{
+		generateSampleSecret("github", generateChecksumToken("ghp_")),
+	}
+	// tokens with an invalid checksum
+	fps := []string{
 		generateSampleSecret("github", "ghp_"+secrets.NewSecret(alphaNumeric("36"))),
 	}
-	return validate(r, tps, nil)
+	return validate(r, tps, fps)
 }
 
 func GitHubFineGrainedPat() *config.Rule {
@@ -30,6 +38,9 @@ func GitHubFineGrainedPat() *config.Rule {
 		RuleID:      "github-fine-grained-pat",
 		Regex:       regexp.MustCompile(`github_pat_[0-9a-zA-Z_]{82}`),
 		Keywords:    []string{"github_pat_"},
+		Tags:        []string{"github", "vcs"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -46,13 +57,21 @@ func GitHubOauth() *config.Rule {
 		RuleID:      "github-oauth",
 		Regex:       regexp.MustCompile(`gho_[0-9a-zA-Z]{36}`),
 		Keywords:    []string{"gho_"},
+		Validator:   validator("crc32-base62"),
+		Tags:        []string{"github", "vcs"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
 	tps := []string{
+		generateSampleSecret("github", generateChecksumToken("gho_")),
+	}
+	// tokens with an invalid checksum
+	fps := []string{
 		generateSampleSecret("github", "gho_"+secrets.NewSecret(alphaNumeric("36"))),
 	}
-	return validate(r, tps, nil)
+	return validate(r, tps, fps)
 }
 
 func GitHubApp() *config.Rule {
@@ -60,16 +79,25 @@ func GitHubApp() *config.Rule {
 	r := config.Rule{
 		Description: "Identified a GitHub App Token, which may compromise GitHub application integrations and source code security.",
 		RuleID:      "github-app-token",
-		Regex:       regexp.MustCompile(`(ghu|ghs)_[0-9a-zA-Z]{36}`),
+		Regex:       regexp.MustCompile(`(?:ghu|ghs)_[0-9a-zA-Z]{36}`),
 		Keywords:    []string{"ghu_", "ghs_"},
+		Validator:   validator("crc32-base62"),
+		Tags:        []string{"github", "vcs"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
 	tps := []string{
+		generateSampleSecret("github", generateChecksumToken("ghu_")),
+		generateSampleSecret("github", generateChecksumToken("ghs_")),
+	}
+	// tokens with an invalid checksum
+	fps := []string{
 		generateSampleSecret("github", "ghu_"+secrets.NewSecret(alphaNumeric("36"))),
 		generateSampleSecret("github", "ghs_"+secrets.NewSecret(alphaNumeric("36"))),
 	}
-	return validate(r, tps, nil)
+	return validate(r, tps, fps)
 }
 
 func GitHubRefresh() *config.Rule {
@@ -79,11 +107,19 @@ func GitHubRefresh() *config.Rule {
 		RuleID:      "github-refresh-token",
 		Regex:       regexp.MustCompile(`ghr_[0-9a-zA-Z]{36}`),
 		Keywords:    []string{"ghr_"},
+		Validator:   validator("crc32-base62"),
+		Tags:        []string{"github", "vcs"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
 	tps := []string{
+		generateSampleSecret("github", generateChecksumToken("ghr_")),
+	}
+	// tokens with an invalid checksum
+	fps := []string{
 		generateSampleSecret("github", "ghr_"+secrets.NewSecret(alphaNumeric("36"))),
 	}
-	return validate(r, tps, nil)
+	return validate(r, tps, fps)
 }
@@ -14,6 +14,9 @@ func GitlabPat() *config.Rule {
 		RuleID:      "gitlab-pat",
 		Regex:       regexp.MustCompile(`glpat-[0-9a-zA-Z\-\_]{20}`),
 		Keywords:    []string{"glpat-"},
+		Tags:        []string{"gitlab", "vcs"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -30,6 +33,9 @@ func GitlabPipelineTriggerToken() *config.Rule {
 		RuleID:      "gitlab-ptt",
 		Regex:       regexp.MustCompile(`glptt-[0-9a-f]{40}`),
 		Keywords:    []string{"glptt-"},
+		Tags:        []string{"gitlab", "vcs"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -46,6 +52,9 @@ func GitlabRunnerRegistrationToken() *config.Rule {
 		RuleID:      "gitlab-rrt",
 		Regex:       regexp.MustCompile(`GR1348941[0-9a-zA-Z\-\_]{20}`),
 		Keywords:    []string{"GR1348941"},
+		Tags:        []string{"gitlab", "vcs"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -16,6 +16,9 @@ func GitterAccessToken() *config.Rule {
 		Keywords: []string{
 			"gitter",
 		},
+		Tags:       []string{"gitter"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -16,6 +16,9 @@ func GoCardless() *config.Rule {
 			"live_",
 			"gocardless",
 		},
+		Tags:       []string{"gocardless", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -11,8 +11,11 @@ func GrafanaApiKey() *config.Rule {
 		Description: "Identified a Grafana API key, which could compromise monitoring dashboards and sensitive data analytics.",
 		RuleID:      "grafana-api-key",
 
-		Regex:    generateUniqueTokenRegex(`eyJrIjoi[A-Za-z0-9]{70,400}={0,2}`, true),
-		Keywords: []string{"eyJrIjoi"},
+		Regex:      generateUniqueTokenRegex(`eyJrIjoi[A-Za-z0-9]{70,400}={0,2}`, true),
+		Keywords:   []string{"eyJrIjoi"},
+		Tags:       []string{"grafana"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -30,8 +33,11 @@ func GrafanaCloudApiToken() *config.Rule {
 		Description: "Found a Grafana cloud API token, risking unauthorized access to cloud-based monitoring services and data exposure.",
 		RuleID:      "grafana-cloud-api-token",
 
-		Regex:    generateUniqueTokenRegex(`glc_[A-Za-z0-9+/]{32,400}={0,2}`, true),
-		Keywords: []string{"glc_"},
+		Regex:      generateUniqueTokenRegex(`glc_[A-Za-z0-9+/]{32,400}={0,2}`, true),
+		Keywords:   []string{"glc_"},
+		Tags:       []string{"grafana"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -49,8 +55,11 @@ func GrafanaServiceAccountToken() *config.Rule {
 		Description: "Discovered a Grafana service account token, posing a risk of compromised monitoring services and data integrity.",
 		RuleID:      "grafana-service-account-token",
 
-		Regex:    generateUniqueTokenRegex(`glsa_[A-Za-z0-9]{32}_[A-Fa-f0-9]{8}`, true),
-		Keywords: []string{"glsa_"},
+		Regex:      generateUniqueTokenRegex(`glsa_[A-Za-z0-9]{32}_[A-Fa-f0-9]{8}`, true),
+		Keywords:   []string{"glsa_"},
+		Tags:       []string{"grafana"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
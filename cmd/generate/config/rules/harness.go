@@ -14,6 +14,9 @@ func HarnessApiKey() *config.Rule {
 		RuleID:      "harness-api-key",
 		Regex:       regexp.MustCompile(`((?:pat|sat)\.[a-zA-Z0-9]{22}\.[a-zA-Z0-9]{24}\.[a-zA-Z0-9]{20})`),
 		Keywords:    []string{"pat.", "sat."},
+		Tags:        []string{"harness"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// Generate a sample secret for validation
@@ -15,6 +15,9 @@ func Hashicorp() *config.Rule {
 		RuleID:      "hashicorp-tf-api-token",
 		Regex:       regexp.MustCompile(`(?i)[a-z0-9]{14}\.atlasv1\.[a-z0-9\-_=]{60,70}`),
 		Keywords:    []string{"atlasv1"},
+		Tags:        []string{"hashicorp"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -33,6 +36,9 @@ func HashicorpField() *config.Rule {
 		Regex:       generateSemiGenericRegex(keywords, fmt.Sprintf(`"%s"`, alphaNumericExtended("8,20")), true),
 		Keywords:    keywords,
 		Path:        regexp.MustCompile(`\.(tf|hcl)$`),
+		Tags:        []string{"hashicorp"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	tps := map[string]string{
@@ -11,7 +11,10 @@ func Heroku() *config.Rule {
 		RuleID:      "heroku-api-key",
 		Regex:       generateSemiGenericRegex([]string{"heroku"}, hex8_4_4_4_12(), true),
 
-		Keywords: []string{"heroku"},
+		Keywords:   []string{"heroku"},
+		Tags:       []string{"heroku", "cloud"},
+		Severity:   config.SeverityCritical,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
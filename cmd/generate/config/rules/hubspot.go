@@ -12,7 +12,10 @@ func HubSpot() *config.Rule {
 		Regex: generateSemiGenericRegex([]string{"hubspot"},
 			`[0-9A-F]{8}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{12}`, true),
 
-		Keywords: []string{"hubspot"},
+		Keywords:   []string{"hubspot"},
+		Tags:       []string{"hubspot"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
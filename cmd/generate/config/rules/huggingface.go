@@ -23,6 +23,9 @@ func HuggingFaceAccessToken() *config.Rule {
 		Keywords: []string{
 			"hf_",
 		},
+		Tags:       []string{"huggingface"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -78,6 +81,9 @@ func HuggingFaceOrganizationApiToken() *config.Rule {
 		Keywords: []string{
 			"api_org_",
 		},
+		Tags:       []string{"huggingface"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
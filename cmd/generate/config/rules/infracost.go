@@ -20,7 +20,10 @@ func InfracostAPIToken() *config.Rule {
 		Regex: generateUniqueTokenRegex(`ico-[a-zA-Z0-9]{32}`, true),
 
 		// Keywords used for string matching on fragments (think of this as a prefilter)
-		Keywords: []string{"ico-"},
+		Keywords:   []string{"ico-"},
+		Tags:       []string{"infracost"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
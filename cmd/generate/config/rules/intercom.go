@@ -12,7 +12,10 @@ func Intercom() *config.Rule {
 		RuleID:      "intercom-api-key",
 		Regex:       generateSemiGenericRegex([]string{"intercom"}, alphaNumericExtended("60"), true),
 
-		Keywords: []string{"intercom"},
+		Keywords:   []string{"intercom"},
+		Tags:       []string{"intercom"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,10 +12,12 @@ func Intra42ClientSecret() *config.Rule {
 		RuleID:      "intra42-client-secret",
 		Regex:       generateUniqueTokenRegex(`s-s4t2(?:ud|af)-[abcdef0123456789]{64}`, true),
 		Keywords: []string{
-			"intra",
 			"s-s4t2ud-",
 			"s-s4t2af-",
 		},
+		Tags:       []string{"42"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
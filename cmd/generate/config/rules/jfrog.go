@@ -24,7 +24,10 @@ func JFrogAPIKey() *config.Rule {
 		Regex: generateSemiGenericRegex(keywords, alphaNumeric("73"), true),
 
 		// Keywords used for string matching on fragments (think of this as a prefilter)
-		Keywords: keywords,
+		Keywords:   keywords,
+		Tags:       []string{"jfrog"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 	// validate
 	tps := []string{
@@ -50,7 +53,10 @@ func JFrogIdentityToken() *config.Rule {
 		Regex: generateSemiGenericRegex(keywords, alphaNumeric("64"), true),
 
 		// Keywords used for string matching on fragments (think of this as a prefilter)
-		Keywords: keywords,
+		Keywords:   keywords,
+		Tags:       []string{"jfrog"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
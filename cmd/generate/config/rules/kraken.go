@@ -16,6 +16,9 @@ func KrakenAccessToken() *config.Rule {
 		Keywords: []string{
 			"kraken",
 		},
+		Tags:       []string{"kraken", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func KucoinAccessToken() *config.Rule {
 		Keywords: []string{
 			"kucoin",
 		},
+		Tags:       []string{"kucoin", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -34,6 +37,9 @@ func KucoinSecretKey() *config.Rule {
 		Keywords: []string{
 			"kucoin",
 		},
+		Tags:       []string{"kucoin", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func LaunchDarklyAccessToken() *config.Rule {
 		Keywords: []string{
 			"launchdarkly",
 		},
+		Tags:       []string{"launchdarkly"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
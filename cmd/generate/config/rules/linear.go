@@ -14,6 +14,9 @@ func LinearAPIToken() *config.Rule {
 		RuleID:      "linear-api-key",
 		Regex:       regexp.MustCompile(`lin_api_(?i)[a-z0-9]{40}`),
 		Keywords:    []string{"lin_api_"},
+		Tags:        []string{"linear"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -30,6 +33,9 @@ func LinearClientSecret() *config.Rule {
 		RuleID:      "linear-client-secret",
 		Regex:       generateSemiGenericRegex([]string{"linear"}, hex("32"), true),
 		Keywords:    []string{"linear"},
+		Tags:        []string{"linear"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
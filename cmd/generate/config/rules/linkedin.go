@@ -19,6 +19,9 @@ func LinkedinClientSecret() *config.Rule {
 			"linkedin",
 			"linked-in",
 		},
+		Tags:       []string{"linkedin"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -42,6 +45,9 @@ func LinkedinClientID() *config.Rule {
 			"linkedin",
 			"linked-in",
 		},
+		Tags:       []string{"linkedin"},
+		Severity:   config.SeverityLow,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
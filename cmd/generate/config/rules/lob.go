@@ -17,6 +17,9 @@ func LobPubAPIToken() *config.Rule {
 			"live_pub",
 			"_pub",
 		},
+		Tags:       []string{"lob"},
+		Severity:   config.SeverityLow,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -36,6 +39,9 @@ func LobAPIToken() *config.Rule {
 			"test_",
 			"live_",
 		},
+		Tags:       []string{"lob"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func MailChimp() *config.Rule {
 		Keywords: []string{
 			"mailchimp",
 		},
+		Tags:       []string{"mailchimp"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
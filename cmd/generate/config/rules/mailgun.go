@@ -15,6 +15,9 @@ func MailGunPrivateAPIToken() *config.Rule {
 		Keywords: []string{
 			"mailgun",
 		},
+		Tags:       []string{"mailgun"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -34,6 +37,9 @@ func MailGunPubAPIToken() *config.Rule {
 		Keywords: []string{
 			"mailgun",
 		},
+		Tags:       []string{"mailgun"},
+		Severity:   config.SeverityLow,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -53,6 +59,9 @@ func MailGunSigningKey() *config.Rule {
 		Keywords: []string{
 			"mailgun",
 		},
+		Tags:       []string{"mailgun"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,7 +12,10 @@ func MapBox() *config.Rule {
 		RuleID:      "mapbox-api-token",
 		Regex:       generateSemiGenericRegex([]string{"mapbox"}, `pk\.[a-z0-9]{60}\.[a-z0-9]{22}`, true),
 
-		Keywords: []string{"mapbox"},
+		Keywords:   []string{"mapbox"},
+		Tags:       []string{"mapbox"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
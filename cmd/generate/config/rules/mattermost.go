@@ -15,6 +15,9 @@ func MattermostAccessToken() *config.Rule {
 		Keywords: []string{
 			"mattermost",
 		},
+		Tags:       []string{"mattermost"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
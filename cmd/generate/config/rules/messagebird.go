@@ -21,6 +21,9 @@ func MessageBirdAPIToken() *config.Rule {
 			"message-bird",
 			"message_bird",
 		},
+		Tags:       []string{"messagebird"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -48,6 +51,9 @@ func MessageBirdClientID() *config.Rule {
 			"message-bird",
 			"message_bird",
 		},
+		Tags:       []string{"messagebird"},
+		Severity:   config.SeverityLow,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
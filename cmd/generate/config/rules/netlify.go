@@ -16,6 +16,9 @@ func NetlifyAccessToken() *config.Rule {
 		Keywords: []string{
 			"netlify",
 		},
+		Tags:       []string{"netlify", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
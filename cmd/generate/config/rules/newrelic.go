@@ -19,6 +19,9 @@ func NewRelicUserID() *config.Rule {
 		Keywords: []string{
 			"NRAK",
 		},
+		Tags:       []string{"new-relic"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -44,6 +47,9 @@ func NewRelicUserKey() *config.Rule {
 			"newrelic",
 			"new_relic",
 		},
+		Tags:       []string{"new-relic"},
+		Severity:   config.SeverityLow,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -67,6 +73,9 @@ func NewRelicBrowserAPIKey() *config.Rule {
 		Keywords: []string{
 			"NRJS-",
 		},
+		Tags:       []string{"new-relic"},
+		Severity:   config.SeverityMedium,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -90,6 +99,9 @@ func NewRelicInsertKey() *config.Rule {
 		Keywords: []string{
 			"NRII-",
 		},
+		Tags:       []string{"new-relic"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -15,11 +15,19 @@ func NPM() *config.Rule {
 		Keywords: []string{
 			"npm_",
 		},
+		Validator:  validator("crc32-base62"),
+		Tags:       []string{"npm"},
+		Severity:   config.SeverityCritical,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
 	tps := []string{
+		generateSampleSecret("npmAccessToken", generateChecksumToken("npm_")),
+	}
+	// tokens with an invalid checksum
+	fps := []string{
 		generateSampleSecret("npmAccessToken", "npm_"+secrets.NewSecret(alphaNumeric("36"))),
 	}
-	return validate(r, tps, nil)
+	return validate(r, tps, fps)
 }
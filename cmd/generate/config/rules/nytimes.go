@@ -19,6 +19,9 @@ func NytimesAccessToken() *config.Rule {
 			"new-york-times",
 			"newyorktimes",
 		},
+		Tags:       []string{"nytimes"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -16,6 +16,9 @@ func OktaAccessToken() *config.Rule {
 		Keywords: []string{
 			"okta",
 		},
+		Tags:       []string{"okta"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -18,6 +18,9 @@ func PlaidAccessID() *config.Rule {
 		Keywords: []string{
 			"plaid",
 		},
+		Tags:       []string{"plaid", "payment"},
+		Severity:   config.SeverityLow,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -38,6 +41,9 @@ func PlaidSecretKey() *config.Rule {
 		Keywords: []string{
 			"plaid",
 		},
+		Tags:       []string{"plaid", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -58,6 +64,9 @@ func PlaidAccessToken() *config.Rule {
 		Keywords: []string{
 			"plaid",
 		},
+		Tags:       []string{"plaid", "payment"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func PlanetScalePassword() *config.Rule {
 		Keywords: []string{
 			"pscale_pw_",
 		},
+		Tags:       []string{"planetscale", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -36,6 +39,9 @@ func PlanetScaleAPIToken() *config.Rule {
 		Keywords: []string{
 			"pscale_tkn_",
 		},
+		Tags:       []string{"planetscale", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -57,6 +63,9 @@ func PlanetScaleOAuthToken() *config.Rule {
 		Keywords: []string{
 			"pscale_oauth_",
 		},
+		Tags:       []string{"planetscale", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
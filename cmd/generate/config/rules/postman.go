@@ -15,6 +15,9 @@ func PostManAPI() *config.Rule {
 		Keywords: []string{
 			"PMAK-",
 		},
+		Tags:       []string{"postman"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
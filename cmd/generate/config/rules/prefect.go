@@ -15,6 +15,9 @@ func Prefect() *config.Rule {
 		Keywords: []string{
 			"pnu_",
 		},
+		Tags:       []string{"prefect"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func PulumiAPIToken() *config.Rule {
 		Keywords: []string{
 			"pul-",
 		},
+		Tags:       []string{"pulumi"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -17,6 +17,9 @@ func PyPiUploadToken() *config.Rule {
 		Keywords: []string{
 			"pypi-AgEIcHlwaS5vcmc",
 		},
+		Tags:       []string{"pypi"},
+		Severity:   config.SeverityCritical,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
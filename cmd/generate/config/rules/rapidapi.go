@@ -16,6 +16,9 @@ func RapidAPIAccessToken() *config.Rule {
 		Keywords: []string{
 			"rapidapi",
 		},
+		Tags:       []string{"rapidapi"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
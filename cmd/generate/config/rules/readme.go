@@ -15,6 +15,9 @@ func ReadMe() *config.Rule {
 		Keywords: []string{
 			"rdme_",
 		},
+		Tags:       []string{"readme"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
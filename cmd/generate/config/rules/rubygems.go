@@ -15,6 +15,9 @@ func RubyGemsAPIToken() *config.Rule {
 		Keywords: []string{
 			"rubygems_",
 		},
+		Tags:       []string{"rubygems"},
+		Severity:   config.SeverityCritical,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
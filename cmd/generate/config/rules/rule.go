@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/rs/zerolog/log"
+	"github.com/zricethezav/gitleaks/v8/cmd/generate/secrets"
 	"github.com/zricethezav/gitleaks/v8/config"
 	"github.com/zricethezav/gitleaks/v8/detect"
 )
@@ -70,6 +71,22 @@ func generateSampleSecret(identifier string, secret string) string {
 	return fmt.Sprintf("%s_api_token = \"%s\"", identifier, secret)
 }
 
+// validator returns the registered validator with the given name.
+func validator(name string) *config.Validator {
+	v, ok := config.GetValidator(name)
+	if !ok {
+		log.Fatal().Msgf("unknown validator %s", name)
+	}
+	return v
+}
+
+// generateChecksumToken generates a token ending in the checksum of its
+// random part, like GitHub and npm tokens.
+func generateChecksumToken(prefix string) string {
+	random := secrets.NewSecret(alphaNumeric("30"))
+	return prefix + random + config.CRC32Base62(random)
+}
+
 func validate(r config.Rule, truePositives []string, falsePositives []string) *config.Rule {
 	// normalize keywords like in the config package
 	var keywords []string
@@ -12,6 +12,9 @@ func ScalingoAPIToken() *config.Rule {
 		RuleID:      "scalingo-api-token",
 		Regex:       generateUniqueTokenRegex(`tk-us-[a-zA-Z0-9-_]{48}`, false),
 		Keywords:    []string{"tk-us-"},
+		Tags:        []string{"scalingo", "cloud"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func SendbirdAccessToken() *config.Rule {
 		Keywords: []string{
 			"sendbird",
 		},
+		Tags:       []string{"sendbird"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -34,6 +37,9 @@ func SendbirdAccessID() *config.Rule {
 		Keywords: []string{
 			"sendbird",
 		},
+		Tags:       []string{"sendbird"},
+		Severity:   config.SeverityLow,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
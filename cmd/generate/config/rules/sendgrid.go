@@ -15,6 +15,9 @@ func SendGridAPIToken() *config.Rule {
 		Keywords: []string{
 			"SG.",
 		},
+		Tags:       []string{"sendgrid"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
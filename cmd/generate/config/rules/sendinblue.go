@@ -15,6 +15,9 @@ func SendInBlueAPIToken() *config.Rule {
 		Keywords: []string{
 			"xkeysib-",
 		},
+		Tags:       []string{"sendinblue"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
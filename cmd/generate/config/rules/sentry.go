@@ -15,6 +15,9 @@ func SentryAccessToken() *config.Rule {
 		Keywords: []string{
 			"sentry",
 		},
+		Tags:       []string{"sentry"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func ShippoAPIToken() *config.Rule {
 		Keywords: []string{
 			"shippo_",
 		},
+		Tags:       []string{"shippo"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -14,6 +14,9 @@ func ShopifySharedSecret() *config.Rule {
 		RuleID:      "shopify-shared-secret",
 		Regex:       regexp.MustCompile(`shpss_[a-fA-F0-9]{32}`),
 		Keywords:    []string{"shpss_"},
+		Tags:        []string{"shopify"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -28,6 +31,9 @@ func ShopifyAccessToken() *config.Rule {
 		RuleID:      "shopify-access-token",
 		Regex:       regexp.MustCompile(`shpat_[a-fA-F0-9]{32}`),
 		Keywords:    []string{"shpat_"},
+		Tags:        []string{"shopify"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -42,6 +48,9 @@ func ShopifyCustomAccessToken() *config.Rule {
 		RuleID:      "shopify-custom-access-token",
 		Regex:       regexp.MustCompile(`shpca_[a-fA-F0-9]{32}`),
 		Keywords:    []string{"shpca_"},
+		Tags:        []string{"shopify"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -56,6 +65,9 @@ func ShopifyPrivateAppAccessToken() *config.Rule {
 		RuleID:      "shopify-private-app-access-token",
 		Regex:       regexp.MustCompile(`shppa_[a-fA-F0-9]{32}`),
 		Keywords:    []string{"shppa_"},
+		Tags:        []string{"shopify"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
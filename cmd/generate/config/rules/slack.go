@@ -19,6 +19,9 @@ func SlackBotToken() *config.Rule {
 		Keywords: []string{
 			"xoxb",
 		},
+		Tags:       []string{"slack"},
+		Severity:   config.SeverityCritical,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -46,8 +49,11 @@ func SlackUserToken() *config.Rule {
 		Description: "Found a Slack User token, posing a risk of unauthorized user impersonation and data access within Slack workspaces.",
 		RuleID:      "slack-user-token",
 		// The last segment seems to be consistently 32 characters. I've made it 28-34 just in case.
-		Regex:    regexp.MustCompile(`(xox[pe](?:-[0-9]{10,13}){3}-[a-zA-Z0-9-]{28,34})`),
-		Keywords: []string{"xoxp-", "xoxe-"},
+		Regex:      regexp.MustCompile(`(xox[pe](?:-[0-9]{10,13}){3}-[a-zA-Z0-9-]{28,34})`),
+		Keywords:   []string{"xoxp-", "xoxe-"},
+		Tags:       []string{"slack"},
+		Severity:   config.SeverityCritical,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -84,8 +90,11 @@ func SlackAppLevelToken() *config.Rule {
 		Description: "Detected a Slack App-level token, risking unauthorized access to Slack applications and workspace data.",
 		RuleID:      "slack-app-token",
 		// This regex is based on a limited number of examples and may not be 100% accurate.
-		Regex:    regexp.MustCompile(`(?i)(xapp-\d-[A-Z0-9]+-\d+-[a-z0-9]+)`),
-		Keywords: []string{"xapp"},
+		Regex:      regexp.MustCompile(`(?i)(xapp-\d-[A-Z0-9]+-\d+-[a-z0-9]+)`),
+		Keywords:   []string{"xapp"},
+		Tags:       []string{"slack"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	tps := []string{
@@ -106,6 +115,9 @@ func SlackConfigurationToken() *config.Rule {
 		RuleID:      "slack-config-access-token",
 		Regex:       regexp.MustCompile(`(?i)(xoxe.xox[bp]-\d-[A-Z0-9]{163,166})`),
 		Keywords:    []string{"xoxe.xoxb-", "xoxe.xoxp-"},
+		Tags:        []string{"slack"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	tps := []string{
@@ -131,6 +143,9 @@ func SlackConfigurationRefreshToken() *config.Rule {
 		RuleID:      "slack-config-refresh-token",
 		Regex:       regexp.MustCompile(`(?i)(xoxe-\d-[A-Z0-9]{146})`),
 		Keywords:    []string{"xoxe-"},
+		Tags:        []string{"slack"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	tps := []string{
@@ -153,6 +168,9 @@ func SlackLegacyBotToken() *config.Rule {
 		Keywords: []string{
 			"xoxb",
 		},
+		Tags:       []string{"slack"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	tps := []string{
@@ -197,6 +215,9 @@ func SlackLegacyWorkspaceToken() *config.Rule {
 			"xoxa",
 			"xoxr",
 		},
+		Tags:       []string{"slack"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	tps := []string{
@@ -227,6 +248,9 @@ func SlackLegacyToken() *config.Rule {
 		RuleID:      "slack-legacy-token",
 		Regex:       regexp.MustCompile(`(xox[os]-\d+-\d+-\d+-[a-fA-F\d]+)`),
 		Keywords:    []string{"xoxo", "xoxs"},
+		Tags:        []string{"slack"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -262,6 +286,9 @@ func SlackWebHookUrl() *config.Rule {
 		Keywords: []string{
 			"hooks.slack.com",
 		},
+		Tags:       []string{"slack"},
+		Severity:   config.SeverityMedium,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -19,8 +19,11 @@ func Snyk() *config.Rule {
 		Description: "Uncovered a Snyk API token, potentially compromising software vulnerability scanning and code security.",
 		RuleID:      "snyk-api-token",
 
-		Regex:    generateSemiGenericRegex(keywords, hex8_4_4_4_12(), true),
-		Keywords: keywords,
+		Regex:      generateSemiGenericRegex(keywords, hex8_4_4_4_12(), true),
+		Keywords:   keywords,
+		Tags:       []string{"snyk"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
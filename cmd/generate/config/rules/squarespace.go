@@ -15,6 +15,9 @@ func SquareSpaceAccessToken() *config.Rule {
 		Keywords: []string{
 			"squarespace",
 		},
+		Tags:       []string{"squarespace"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -26,6 +26,9 @@ func SumoLogicAccessID() *config.Rule {
 				regexp.MustCompile(`sumOf`),
 			},
 		},
+		Tags:       []string{"sumologic"},
+		Severity:   config.SeverityLow,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -62,6 +65,9 @@ func SumoLogicAccessToken() *config.Rule {
 		Keywords: []string{
 			"sumo",
 		},
+		Tags:       []string{"sumologic"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
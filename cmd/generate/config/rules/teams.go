@@ -19,6 +19,9 @@ func TeamsWebhook() *config.Rule {
 			"webhookb2",
 			"IncomingWebhook",
 		},
+		Tags:       []string{"microsoft-teams"},
+		Severity:   config.SeverityMedium,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
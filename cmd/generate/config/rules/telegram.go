@@ -17,6 +17,9 @@ func TelegramBotToken() *config.Rule {
 		Keywords: []string{
 			"telegr",
 		},
+		Tags:       []string{"telegram"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceHigh,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func TravisCIAccessToken() *config.Rule {
 		Keywords: []string{
 			"travis",
 		},
+		Tags:       []string{"travis-ci"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
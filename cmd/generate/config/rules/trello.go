@@ -15,6 +15,9 @@ func TrelloAccessToken() *config.Rule {
 		Keywords: []string{
 			"trello",
 		},
+		Tags:       []string{"trello"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
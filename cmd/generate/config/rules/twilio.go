@@ -14,6 +14,9 @@ func Twilio() *config.Rule {
 		RuleID:      "twilio-api-key",
 		Regex:       regexp.MustCompile(`SK[0-9a-fA-F]{32}`),
 		Keywords:    []string{"twilio"},
+		Tags:        []string{"twilio"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -14,6 +14,9 @@ func TwitchAPIToken() *config.Rule {
 		Keywords: []string{
 			"twitch",
 		},
+		Tags:       []string{"twitch"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,6 +12,9 @@ func TwitterAPIKey() *config.Rule {
 		RuleID:      "twitter-api-key",
 		Regex:       generateSemiGenericRegex([]string{"twitter"}, alphaNumeric("25"), true),
 		Keywords:    []string{"twitter"},
+		Tags:        []string{"twitter"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -28,6 +31,9 @@ func TwitterAPISecret() *config.Rule {
 		RuleID:      "twitter-api-secret",
 		Regex:       generateSemiGenericRegex([]string{"twitter"}, alphaNumeric("50"), true),
 		Keywords:    []string{"twitter"},
+		Tags:        []string{"twitter"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -44,7 +50,10 @@ func TwitterBearerToken() *config.Rule {
 		RuleID:      "twitter-bearer-token",
 		Regex:       generateSemiGenericRegex([]string{"twitter"}, "A{22}[a-zA-Z0-9%]{80,100}", true),
 
-		Keywords: []string{"twitter"},
+		Keywords:   []string{"twitter"},
+		Tags:       []string{"twitter"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -61,6 +70,9 @@ func TwitterAccessToken() *config.Rule {
 		RuleID:      "twitter-access-token",
 		Regex:       generateSemiGenericRegex([]string{"twitter"}, "[0-9]{15,25}-[a-zA-Z0-9]{20,40}", true),
 		Keywords:    []string{"twitter"},
+		Tags:        []string{"twitter"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -77,6 +89,9 @@ func TwitterAccessSecret() *config.Rule {
 		RuleID:      "twitter-access-secret",
 		Regex:       generateSemiGenericRegex([]string{"twitter"}, alphaNumeric("45"), true),
 		Keywords:    []string{"twitter"},
+		Tags:        []string{"twitter"},
+		Severity:    config.SeverityHigh,
+		Confidence:  config.ConfidenceMedium,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func Typeform() *config.Rule {
 		Keywords: []string{
 			"tfp_",
 		},
+		Tags:       []string{"typeform"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -12,6 +12,9 @@ func VaultServiceToken() *config.Rule {
 		RuleID:      "vault-service-token",
 		Regex:       generateUniqueTokenRegex(`hvs\.[a-z0-9_-]{90,100}`, true),
 		Keywords:    []string{"hvs"},
+		Tags:        []string{"vault"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -28,6 +31,9 @@ func VaultBatchToken() *config.Rule {
 		RuleID:      "vault-batch-token",
 		Regex:       generateUniqueTokenRegex(`hvb\.[a-z0-9_-]{138,212}`, true),
 		Keywords:    []string{"hvb"},
+		Tags:        []string{"vault"},
+		Severity:    config.SeverityCritical,
+		Confidence:  config.ConfidenceHigh,
 	}
 
 	// validate
@@ -15,6 +15,9 @@ func YandexAWSAccessToken() *config.Rule {
 		Keywords: []string{
 			"yandex",
 		},
+		Tags:       []string{"yandex", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -36,6 +39,9 @@ func YandexAPIKey() *config.Rule {
 		Keywords: []string{
 			"yandex",
 		},
+		Tags:       []string{"yandex", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -57,6 +63,9 @@ func YandexAccessToken() *config.Rule {
 		Keywords: []string{
 			"yandex",
 		},
+		Tags:       []string{"yandex", "cloud"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -14,6 +14,9 @@ func ZendeskSecretKey() *config.Rule {
 		Keywords: []string{
 			"zendesk",
 		},
+		Tags:       []string{"zendesk"},
+		Severity:   config.SeverityHigh,
+		Confidence: config.ConfidenceMedium,
 	}
 
 	// validate
@@ -36,6 +36,7 @@ func runProtect(cmd *cobra.Command, args []string) {
 	}
 	start := time.Now()
 	detector := Detector(cmd, cfg, source)
+	addNestedConfigs(cmd, detector, source)
 
 	// start git scan
 	var findings []report.Finding
@@ -43,7 +44,9 @@ func runProtect(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatal().Err(err).Msg("")
 	}
-	findings, err = detector.DetectGit(gitCmd)
+	ctx, cancel := scanContext(cmd)
+	defer cancel()
+	findings, err = detector.DetectGitContext(ctx, gitCmd)
 
 	findingSummaryAndExit(findings, cmd, cfg, exitCode, start, err)
 }
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -43,6 +47,7 @@ func init() {
 	cobra.OnInitialize(initLog)
 	rootCmd.PersistentFlags().StringP("config", "c", "", configDescription)
 	rootCmd.PersistentFlags().Int("exit-code", 1, "exit code when leaks have been encountered")
+	rootCmd.PersistentFlags().String("fail-on", "", "only exit with --exit-code if a leak has at least this severity (info, low, medium, high, critical), leaks found by rules without a severity always count")
 	rootCmd.PersistentFlags().StringP("source", "s", ".", "path to source")
 	rootCmd.PersistentFlags().StringP("report-path", "r", "", "report file")
 	rootCmd.PersistentFlags().StringP("report-format", "f", "json", "output format (json, csv, junit, sarif)")
@@ -51,14 +56,25 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "show verbose output from scan")
 	rootCmd.PersistentFlags().BoolP("no-color", "", false, "turn off color for verbose output")
 	rootCmd.PersistentFlags().Int("max-target-megabytes", 0, "files larger than this will be skipped")
+	rootCmd.PersistentFlags().Int("max-decode-depth", 0, "decode base64, hex, percent and unicode escaped content and scan it again up to this many times, 0 disables decoding")
+	rootCmd.PersistentFlags().Int("max-archive-depth", 0, "allow scanning into nested archives (zip, jar, war, whl, tar, tar.gz) up to this depth, 0 disables archive scanning")
 	rootCmd.PersistentFlags().BoolP("ignore-gitleaks-allow", "", false, "ignore gitleaks:allow comments")
+	rootCmd.PersistentFlags().Bool("require-allow-reason", false, "ignore the gitleaks:allow comments without a reason=\"...\"")
+	rootCmd.PersistentFlags().Bool("fail-on-expired-allowlist", false, "exit with an error instead of warning when an allowlist of the config expired")
+	rootCmd.PersistentFlags().Bool("include-suppressed", false, "report the findings suppressed by allowlists, stop words, entropy, gitleaks:allow, .gitleaksignore or the baseline, with Suppressed set and a SuppressionReason, they do not change the exit code")
 	rootCmd.PersistentFlags().Uint("redact", 0, "redact secrets from logs and stdout. To redact only parts of the secret just apply a percent value from 0..100. For example --redact=20 (default 100%)")
 	rootCmd.Flag("redact").NoOptDefVal = "100"
 	rootCmd.PersistentFlags().Bool("no-banner", false, "suppress banner")
 	rootCmd.PersistentFlags().String("log-opts", "", "git log options")
-	rootCmd.PersistentFlags().StringSlice("enable-rule", []string{}, "only enable specific rules by id, ex: `gitleaks detect --enable-rule=atlassian-api-token --enable-rule=slack-access-token`")
+	rootCmd.PersistentFlags().StringSlice("enable-rule", []string{}, "only enable specific rules by id or glob, ex: `gitleaks detect --enable-rule=atlassian-api-token --enable-rule='slack-*'`")
+	rootCmd.PersistentFlags().StringSlice("disable-rule", []string{}, "disable rules by id or glob, even enabled ones, ex: `gitleaks detect --disable-rule='generic-*'`")
+	rootCmd.PersistentFlags().StringSlice("enable-tag", []string{}, "only enable the rules with one of these tags or globs, along with the rules of --enable-rule, ex: `gitleaks protect --enable-tag=cloud --enable-tag=vcs`")
+	rootCmd.PersistentFlags().StringSlice("disable-tag", []string{}, "disable the rules with one of these tags or globs, even enabled ones")
 	rootCmd.PersistentFlags().StringP("gitleaks-ignore-path", "i", ".", "path to .gitleaksignore file or folder containing one")
+	rootCmd.PersistentFlags().Bool("nested-configs", false, "apply the .gitleaks.toml files of the subdirectories of the source, by default only when the config is not set by --config or GITLEAKS_CONFIG")
 	rootCmd.PersistentFlags().Bool("follow-symlinks", false, "scan files that are symlinks to other files")
+	rootCmd.PersistentFlags().Int("threads", detect.DefaultThreads, "number of files, or files changed by commits, scanned at the same time")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "stop the scan after this long, ex: 10m, and report the findings found so far as a partial scan, 0 disables the timeout")
 	err := viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 	if err != nil {
 		log.Fatal().Msgf("err binding config %s", err.Error())
@@ -209,10 +225,22 @@ func Detector(cmd *cobra.Command, cfg config.Config, source string) *detect.Dete
 	if detector.MaxTargetMegaBytes, err = cmd.Flags().GetInt("max-target-megabytes"); err != nil {
 		log.Fatal().Err(err).Msg("")
 	}
+	if detector.MaxArchiveDepth, err = cmd.Flags().GetInt("max-archive-depth"); err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	if detector.MaxDecodeDepth, err = cmd.Flags().GetInt("max-decode-depth"); err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
 	// set ignore gitleaks:allow flag
 	if detector.IgnoreGitleaksAllow, err = cmd.Flags().GetBool("ignore-gitleaks-allow"); err != nil {
 		log.Fatal().Err(err).Msg("")
 	}
+	if detector.RequireAllowReason, err = cmd.Flags().GetBool("require-allow-reason"); err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	if detector.IncludeSuppressed, err = cmd.Flags().GetBool("include-suppressed"); err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
 
 	gitleaksIgnorePath, err := cmd.Flags().GetString("gitleaks-ignore-path")
 	if err != nil {
@@ -246,62 +274,359 @@ func Detector(cmd *cobra.Command, cfg config.Config, source string) *detect.Dete
 		}
 	}
 
-	// If set, only apply rules that are defined in the flag
-	rules, _ := cmd.Flags().GetStringSlice("enable-rule")
-	if len(rules) > 0 {
-		log.Info().Msg("Overriding enabled rules: " + strings.Join(rules, ", "))
-		ruleOverride := make(map[string]config.Rule)
-		for _, ruleName := range rules {
-			if rule, ok := cfg.Rules[ruleName]; ok {
-				ruleOverride[ruleName] = rule
-			} else {
-				log.Fatal().Msgf("Requested rule %s not found in rules", ruleName)
-			}
-		}
-		detector.Config.Rules = ruleOverride
-	}
+	selectRules(cmd, &detector.Config)
+	checkExpiredAllowlists(cmd, detector.Config)
 
 	// set follow symlinks flag
 	if detector.FollowSymlinks, err = cmd.Flags().GetBool("follow-symlinks"); err != nil {
 		log.Fatal().Err(err).Msg("")
 	}
+	threads, err := cmd.Flags().GetInt("threads")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	if threads < 1 {
+		log.Fatal().Msgf("invalid --threads %d, must be at least 1", threads)
+	}
+	detector.SetThreads(threads)
+
+	// --fail-on is only used once the scan is complete, validate it now
+	failOnLevel(cmd)
 	return detector
 }
 
+// addNestedConfigs applies the .gitleaks.toml files found in the
+// subdirectories of source to the files under them, with the rules selected
+// by the rule selection flags of cmd. Anyone who can commit to the source can
+// add a nested config weakening the scan of their directory, so when the
+// config is set by --config or GITLEAKS_CONFIG, usually to enforce it, nested
+// configs are only applied with --nested-configs.
+func addNestedConfigs(cmd *cobra.Command, detector *detect.Detector, source string) {
+	nested, err := cmd.Flags().GetBool("nested-configs")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	if !cmd.Flags().Changed("nested-configs") {
+		nested = !explicitConfig(cmd)
+	}
+	if !nested {
+		log.Debug().Msg("skipping nested configs, the config is set explicitly and --nested-configs is not")
+		return
+	}
+	now := time.Now()
+	reported := make(map[string]bool)
+	for _, msg := range detector.Config.ExpiredAllowlists(now) {
+		reported[msg] = true
+	}
+	var expired []string
+	err = detector.AddNestedConfigs(source, func(cfg *config.Config) {
+		// patterns matching no rule of a nested config are not an error, the
+		// rules may be disabled by the nested config
+		cfg.SelectRules(ruleSelection(cmd, *cfg))
+
+		// nested configs repeat the allowlists of their parent
+		for _, msg := range cfg.ExpiredAllowlists(now) {
+			if !reported[msg] {
+				reported[msg] = true
+				expired = append(expired, cfg.Path+": "+msg)
+			}
+		}
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load nested configs")
+	}
+	reportExpiredAllowlists(cmd, expired)
+}
+
+// explicitConfig reports whether the config is set by --config or
+// GITLEAKS_CONFIG rather than found in the source.
+func explicitConfig(cmd *cobra.Command) bool {
+	cfgPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	return cfgPath != "" || os.Getenv("GITLEAKS_CONFIG") != ""
+}
+
+// trustedConfig reports whether the config is chosen by whoever runs
+// gitleaks, the default config or a config set by --config or
+// GITLEAKS_CONFIG, rather than found in the source.
+func trustedConfig(cmd *cobra.Command) bool {
+	// the default config is read without a file
+	return explicitConfig(cmd) || viper.ConfigFileUsed() == ""
+}
+
+// selectRules keeps the rules of cfg selected by the rule selection keys of
+// cfg and the rule selection flags of cmd, which take precedence. A selection
+// leaving no rule, or with an enable pattern matching no rule, is fatal.
+func selectRules(cmd *cobra.Command, cfg *config.Config) {
+	selection := ruleSelection(cmd, *cfg)
+	if selection.Empty() {
+		return
+	}
+	total := len(cfg.Rules)
+	enable := make(map[string]bool)
+	for _, pattern := range append(append([]string{}, selection.EnableRules...), selection.EnableTags...) {
+		enable[pattern] = true
+	}
+	var unmatchedEnable []string
+	for _, pattern := range cfg.SelectRules(selection) {
+		if enable[pattern] {
+			unmatchedEnable = append(unmatchedEnable, pattern)
+			continue
+		}
+		log.Warn().Msgf("%s matches no rule", pattern)
+	}
+	if len(unmatchedEnable) > 0 {
+		log.Fatal().Msgf("%s enables no rule, check the rule IDs and tags with `gitleaks rules list`", strings.Join(unmatchedEnable, ", "))
+	}
+	if len(cfg.Rules) == 0 {
+		log.Fatal().Msgf("the rule selection leaves none of the %d rules enabled", total)
+	}
+	log.Info().Msgf("%d of %d rules enabled", len(cfg.Rules), total)
+}
+
+// ruleSelection returns the rule selection of cfg with the lists set by the
+// rule selection flags of cmd replaced.
+func ruleSelection(cmd *cobra.Command, cfg config.Config) config.RuleSelection {
+	selection := cfg.RuleSelection
+	for flag, list := range map[string]*[]string{
+		"enable-rule":  &selection.EnableRules,
+		"disable-rule": &selection.DisableRules,
+		"enable-tag":   &selection.EnableTags,
+		"disable-tag":  &selection.DisableTags,
+	} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+		values, err := cmd.Flags().GetStringSlice(flag)
+		if err != nil {
+			log.Fatal().Err(err).Msg("")
+		}
+		*list = values
+	}
+	if err := selection.Validate(); err != nil {
+		log.Fatal().Err(err).Msg("invalid rule selection")
+	}
+	return selection
+}
+
+// partialScanExitCode is the exit code of a scan stopped by --timeout or a
+// signal before it completed.
+const partialScanExitCode = 2
+
+// scanContext returns the context of a scan, done once --timeout elapsed or
+// gitleaks is interrupted by SIGINT or SIGTERM.
+func scanContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			log.Warn().Msgf("received %s, stopping the scan, signal again to exit now", sig)
+			cancel()
+		case <-ctx.Done():
+		}
+		// a second signal exits at once
+		signal.Stop(signals)
+	}()
+	if timeout <= 0 {
+		return ctx, cancel
+	}
+	ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancelTimeout()
+		cancel()
+	}
+}
+
+// partialReport returns the marker of the report of a scan stopped by err,
+// or nil if the scan completed.
+func partialReport(err error) *report.Partial {
+	var partialErr *detect.PartialScanError
+	if !errors.As(err, &partialErr) {
+		return nil
+	}
+	partial := &report.Partial{
+		Reason:     "interrupted",
+		LastCommit: partialErr.Progress.LastCommit,
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		partial.Reason = "timed out"
+	}
+	return partial
+}
+
 func findingSummaryAndExit(findings []report.Finding, cmd *cobra.Command, cfg config.Config, exitCode int, start time.Time, err error) {
+	findingSummary(findings, cmd, cfg, start, err)
+	exitScan(findings, cmd, exitCode, err)
+}
+
+// findingSummary logs the summary of a scan stopped by err, or completed if
+// err is nil, and writes its report.
+func findingSummary(findings []report.Finding, cmd *cobra.Command, cfg config.Config, start time.Time, err error) {
+	logSummary(findings, start, err)
+	writeReport(findings, cmd, cfg, err)
+}
+
+// logSummary logs the summary of a scan stopped by err, or completed if err
+// is nil.
+func logSummary(findings []report.Finding, start time.Time, err error) {
+	leaks := len(findings) - countSuppressed(findings)
 	if err == nil {
 		log.Info().Msgf("scan completed in %s", FormatDuration(time.Since(start)))
-		if len(findings) != 0 {
-			log.Warn().Msgf("leaks found: %d", len(findings))
+		if leaks != 0 {
+			log.Warn().Msgf("leaks found: %d", leaks)
 		} else {
 			log.Info().Msg("no leaks found")
 		}
 	} else {
 		log.Warn().Msgf("partial scan completed in %s", FormatDuration(time.Since(start)))
-		if len(findings) != 0 {
-			log.Warn().Msgf("%d leaks found in partial scan", len(findings))
+		if leaks != 0 {
+			log.Warn().Msgf("%d leaks found in partial scan", leaks)
 		} else {
 			log.Warn().Msg("no leaks found in partial scan")
 		}
 	}
+	if suppressed := countSuppressed(findings); suppressed != 0 {
+		log.Info().Msgf("suppressed findings: %d", suppressed)
+	}
 
-	// write report if desired
+	partial := partialReport(err)
+	if partial != nil && partial.LastCommit != "" {
+		log.Warn().Msgf("last fully scanned commit: %s", partial.LastCommit)
+		// git log lists the commits of several branches, or of merged
+		// branches, by date, so the commits left to scan are not all
+		// ancestors of the last one scanned
+		log.Info().Msgf("the history of a single branch without merges resumes with --log-opts=\"%s^\", "+
+			"other scans have to start over, with --state-file to only scan the new commits after that", partial.LastCommit)
+	}
+}
+
+// writeReport writes the report of a scan stopped by err, or completed if err
+// is nil, if --report-path is set.
+func writeReport(findings []report.Finding, cmd *cobra.Command, cfg config.Config, err error) {
+	partial := partialReport(err)
 	reportPath, _ := cmd.Flags().GetString("report-path")
 	ext, _ := cmd.Flags().GetString("report-format")
 	if reportPath != "" {
-		if err := report.Write(findings, cfg, ext, reportPath); err != nil {
+		if err := report.WritePartial(findings, cfg, ext, reportPath, partial); err != nil {
 			log.Fatal().Err(err).Msg("could not write")
 		}
 	}
+}
+
+// exitScan exits with the exit code of a scan stopped by err, or with
+// exitCode if it completed and findings has failing leaks.
+func exitScan(findings []report.Finding, cmd *cobra.Command, exitCode int, err error) {
+	if code := scanExitCode(findings, cmd, exitCode, err); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// scanExitCode returns the exit code of a scan stopped by err, or exitCode if
+// it completed and findings has failing leaks, or 0.
+func scanExitCode(findings []report.Finding, cmd *cobra.Command, exitCode int, err error) int {
+	if partialReport(err) != nil {
+		return partialScanExitCode
+	}
+	if err != nil {
+		return 1
+	}
+	if countFailing(findings, failOnLevel(cmd)) != 0 {
+		return exitCode
+	}
+	return 0
+}
+
+// checkExpiredAllowlists warns about the allowlists of cfg which expired and
+// are no longer applied, or exits if --fail-on-expired-allowlist is set.
+func checkExpiredAllowlists(cmd *cobra.Command, cfg config.Config) {
+	reportExpiredAllowlists(cmd, cfg.ExpiredAllowlists(time.Now()))
+}
+
+// reportExpiredAllowlists warns about the expired allowlists described by
+// expired, or exits if --fail-on-expired-allowlist is set.
+func reportExpiredAllowlists(cmd *cobra.Command, expired []string) {
+	fail, err := cmd.Flags().GetBool("fail-on-expired-allowlist")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	for _, msg := range expired {
+		if fail {
+			log.Error().Msgf("%s", msg)
+		} else {
+			log.Warn().Msgf("%s and is no longer applied", msg)
+		}
+	}
+	if fail && len(expired) > 0 {
+		log.Fatal().Msgf("%d allowlist(s) expired", len(expired))
+	}
+}
 
+// warnUnusedIgnores warns, in a single line, about the .gitleaksignore entries
+// which did not match any finding, and lists them at debug level. It must only
+// be called after a complete scan of the full history, other scans miss the
+// findings of some entries.
+func warnUnusedIgnores(detector *detect.Detector) {
+	unused := detector.UnusedGitleaksIgnoreEntries()
+	if len(unused) == 0 {
+		return
+	}
+	for _, entry := range unused {
+		log.Debug().Msgf("unused .gitleaksignore entry %s", entry)
+	}
+	log.Warn().Msgf("%d .gitleaksignore entries matched no finding and can be removed, run with --log-level debug to list them", len(unused))
+}
+
+// failOnLevel returns the severity level set by --fail-on, or -1 if every
+// finding should fail the scan.
+func failOnLevel(cmd *cobra.Command) int {
+	failOn, err := cmd.Flags().GetString("fail-on")
 	if err != nil {
-		os.Exit(1)
+		log.Fatal().Err(err).Msg("")
+	}
+	if failOn == "" {
+		return -1
+	}
+	level, ok := config.SeverityLevel(failOn)
+	if !ok {
+		log.Fatal().Msgf("invalid --fail-on severity %s, must be one of info, low, medium, high, critical", failOn)
 	}
+	return level
+}
 
-	if len(findings) != 0 {
-		os.Exit(exitCode)
+// countFailing returns the number of findings with a severity of at least
+// level. Findings without a severity are always counted, suppressed findings
+// never are.
+func countFailing(findings []report.Finding, level int) int {
+	count := 0
+	for _, f := range findings {
+		if f.Suppressed {
+			continue
+		}
+		if severity, ok := config.SeverityLevel(f.Severity); ok && severity < level {
+			continue
+		}
+		count++
 	}
+	return count
+}
 
+// countSuppressed returns the number of suppressed findings.
+func countSuppressed(findings []report.Finding) int {
+	count := 0
+	for _, f := range findings {
+		if f.Suppressed {
+			count++
+		}
+	}
+	return count
 }
 
 func fileExists(fileName string) bool {
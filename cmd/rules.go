@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/detect"
+)
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesTestCmd)
+	rulesCmd.AddCommand(rulesListCmd)
+	rulesCmd.AddCommand(rulesShowCmd)
+	rulesListCmd.Flags().StringSlice("tag", []string{}, "only list the rules with one of these tags")
+	rulesListCmd.Flags().String("format", "table", "output format (table, json)")
+}
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "inspect and test the rules of a config",
+}
+
+var rulesTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "check the truePositives and falsePositives of the rules",
+	Run:   runRulesTest,
+}
+
+var rulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the rules of the config",
+	Args:  cobra.NoArgs,
+	Run:   runRulesList,
+}
+
+var rulesShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "show the regex, allowlist and origin of a rule",
+	Args:  cobra.ExactArgs(1),
+	Run:   runRulesShow,
+}
+
+// listedRule is a rule printed by `rules list --format json`
+type listedRule struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags"`
+	Keywords    []string `json:"keywords"`
+	Entropy     float64  `json:"entropy,omitempty"`
+	Path        string   `json:"path,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	Confidence  string   `json:"confidence,omitempty"`
+}
+
+func runRulesList(cmd *cobra.Command, args []string) {
+	cfg := resolveConfig(nil)
+	selectRules(cmd, &cfg)
+	tags, err := cmd.Flags().GetStringSlice("tag")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+
+	if err := writeRules(os.Stdout, listRules(cfg, tags), format); err != nil {
+		log.Fatal().Err(err).Msg("")
+	}
+}
+
+// listRules returns the rules of the config with one of tags, or all of them
+// if tags is empty.
+func listRules(cfg config.Config, tags []string) []listedRule {
+	var rules []listedRule
+	for _, rule := range cfg.GetOrderedRules() {
+		if len(tags) > 0 && !hasAnyTag(rule, tags) {
+			continue
+		}
+		l := listedRule{
+			ID:          rule.RuleID,
+			Description: rule.Description,
+			Tags:        rule.Tags,
+			Keywords:    rule.Keywords,
+			Entropy:     rule.Entropy,
+			Severity:    rule.Severity,
+			Confidence:  rule.Confidence,
+		}
+		if rule.Path != nil {
+			l.Path = rule.Path.String()
+		}
+		rules = append(rules, l)
+	}
+	return rules
+}
+
+// writeRules writes the listed rules to w in format, table or json.
+func writeRules(w io.Writer, rules []listedRule, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		if rules == nil {
+			rules = []listedRule{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", " ")
+		return encoder.Encode(rules)
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tDESCRIPTION\tTAGS\tKEYWORDS\tENTROPY\tPATH")
+		for _, r := range rules {
+			entropy := ""
+			if r.Entropy != 0 {
+				entropy = strconv.FormatFloat(r.Entropy, 'f', -1, 64)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, truncate(r.Description, 60),
+				strings.Join(r.Tags, ","), strings.Join(r.Keywords, ","), entropy, r.Path)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown format %s, must be one of table, json", format)
+	}
+}
+
+func runRulesShow(cmd *cobra.Command, args []string) {
+	cfg := resolveConfig(nil)
+	rule, ok := cfg.Rules[args[0]]
+	if !ok {
+		log.Fatal().Msgf("rule %s not found in %s", args[0], cfg.Path)
+	}
+	origin := func(source string) string {
+		switch {
+		case source == config.DefaultSource:
+			return source
+		case source == "" && cfg.Path == config.DefaultSource:
+			return cfg.Path
+		case source == "":
+			return cfg.Path + " (local)"
+		default:
+			return source + " (extended)"
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	field := func(name string, value interface{}) {
+		if s := fmt.Sprint(value); s != "" && s != "0" {
+			fmt.Fprintf(w, "%s:\t%s\n", name, s)
+		}
+	}
+	sources := cfg.Sources.Rules[rule.RuleID]
+	if len(sources) == 0 {
+		sources = []string{""}
+	}
+	field("ID", rule.RuleID)
+	field("Description", rule.Description)
+	field("Defined in", origin(sources[0]))
+	for _, source := range sources[1:] {
+		field("Overridden in", origin(source))
+	}
+	if rule.Regex != nil {
+		field("Regex", rule.Regex.String())
+	}
+	field("Secret group", rule.SecretGroup)
+	field("Entropy", rule.Entropy)
+	if rule.Path != nil {
+		field("Path", rule.Path.String())
+	}
+	field("Keywords", strings.Join(rule.Keywords, ", "))
+	field("Tags", strings.Join(rule.Tags, ", "))
+	field("Severity", rule.Severity)
+	field("Confidence", rule.Confidence)
+	if rule.Validator != nil {
+		field("Validator", rule.Validator.Name)
+	}
+	if rule.Verify != nil {
+		field("Verify", rule.Verify.Method+" "+rule.Verify.URL)
+	}
+	for _, req := range rule.RequiredRules {
+		if req.RuleID != "" {
+			field("Requires", req.RuleID)
+		} else {
+			field("Requires", req.Regex.String())
+		}
+	}
+
+	entrySources := cfg.Sources.EntrySources(rule.RuleID)
+	entries := func(name, key string, values []string) {
+		for i, value := range values {
+			source := ""
+			if i < len(entrySources[key]) {
+				source = entrySources[key][i]
+			}
+			fmt.Fprintf(w, "%s:\t%s\t(%s)\n", name, value, origin(source))
+		}
+	}
+	details := func(prefix string, a config.Allowlist) {
+		field(prefix+" description", a.Description)
+		field(prefix+" owner", a.Owner)
+		field(prefix+" reason", a.Reason)
+		field(prefix+" condition", a.Condition)
+		if !a.Expires.IsZero() {
+			expires := a.Expires.Format("2006-01-02")
+			if a.Expired(time.Now()) {
+				expires += " (expired)"
+			}
+			field(prefix+" expires", expires)
+		}
+	}
+	details("Allowlist", rule.Allowlist)
+	field("Allowlist regex target", rule.Allowlist.RegexTarget)
+	entries("Allowlist regex", "regexes", regexStrings(rule.Allowlist.Regexes))
+	entries("Allowlist path", "paths", regexStrings(rule.Allowlist.Paths))
+	entries("Allowlist commit", "commits", rule.Allowlist.Commits)
+	field("Allowlist stop words", len(rule.Allowlist.StopWords))
+
+	namedSources := cfg.Sources.NamedAllowlists[rule.RuleID]
+	for i, a := range rule.Allowlists {
+		source := ""
+		if i < len(namedSources) {
+			source = namedSources[i]
+		}
+		// the allowlists of overridden rules are unnamed
+		prefix := "Extended allowlist"
+		if a.Name != "" {
+			prefix = fmt.Sprintf("Allowlist %q", a.Name)
+		}
+		field(prefix, origin(source))
+		details(prefix, a)
+		field(prefix+" regex target", a.RegexTarget)
+		for _, re := range regexStrings(a.Regexes) {
+			field(prefix+" regex", re)
+		}
+		for _, path := range regexStrings(a.Paths) {
+			field(prefix+" path", path)
+		}
+		for _, commit := range a.Commits {
+			field(prefix+" commit", commit)
+		}
+		field(prefix+" stop words", len(a.StopWords))
+	}
+	_ = w.Flush()
+}
+
+func hasAnyTag(rule config.Rule, tags []string) bool {
+	for _, tag := range tags {
+		for _, ruleTag := range rule.Tags {
+			if strings.EqualFold(tag, ruleTag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func regexStrings(res []*regexp.Regexp) []string {
+	var s []string
+	for _, re := range res {
+		s = append(s, re.String())
+	}
+	return s
+}
+
+// truncate shortens s to n runes, ending it with an ellipsis if n leaves
+// room for it.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(runes[:n])
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+func runRulesTest(cmd *cobra.Command, args []string) {
+	cfg := resolveConfig(nil)
+	selectRules(cmd, &cfg)
+
+	results := detect.RunRuleTests(cfg)
+	if len(results) == 0 {
+		log.Warn().Msg("no rule declares truePositives or falsePositives")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RULE\tCASE\tRESULT")
+	failed := 0
+	for _, r := range results {
+		result := "PASS"
+		if !r.Passed {
+			result = "FAIL"
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.RuleID, ruleTestCase(r), result)
+	}
+	_ = w.Flush()
+
+	for _, r := range results {
+		if r.Passed {
+			continue
+		}
+		fmt.Printf("\n--- FAIL: %s %s\n", r.RuleID, ruleTestCase(r))
+		fmt.Printf("    fragment: %s\n", strings.ReplaceAll(r.Fragment, "\n", "\n              "))
+		if r.TruePositive {
+			fmt.Println("    expected: at least one finding")
+			fmt.Println("    got:      no findings")
+			continue
+		}
+		fmt.Println("    expected: no findings")
+		fmt.Printf("    got:      %d finding(s)\n", len(r.Findings))
+		for _, f := range r.Findings {
+			fmt.Printf("      - secret %q at line %d, columns %d-%d\n", f.Secret, f.StartLine+1, f.StartColumn, f.EndColumn)
+		}
+	}
+
+	if failed > 0 {
+		log.Error().Msgf("%d of %d rule tests failed", failed, len(results))
+		os.Exit(1)
+	}
+	log.Info().Msgf("%d rule tests passed", len(results))
+}
+
+// ruleTestCase names the case of a rule test result after the TOML key it is
+// declared in.
+func ruleTestCase(r detect.RuleTestResult) string {
+	key := "falsePositive"
+	if r.TruePositive {
+		key = "truePositive"
+	}
+	if r.Index < 0 {
+		return fmt.Sprintf("%sPaths[%q]", key, r.Path)
+	}
+	return fmt.Sprintf("%ss[%d]", key, r.Index)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+)
+
+func TestListRules(t *testing.T) {
+	cfg := config.Config{
+		Rules: map[string]config.Rule{
+			"aws-access-key": {
+				RuleID:      "aws-access-key",
+				Description: "AWS Access Key",
+				Regex:       regexp.MustCompile(`AKIA[A-Z0-9]{16}`),
+				Tags:        []string{"key", "AWS"},
+				Keywords:    []string{"akia"},
+			},
+			"sakura-token": {
+				RuleID:      "sakura-token",
+				Description: strings.Repeat("さくらのクラウド", 10),
+				Regex:       regexp.MustCompile(`sakura_[a-z0-9]{32}`),
+				Tags:        []string{"cloud"},
+				Keywords:    []string{"sakura_"},
+				Entropy:     3.5,
+				Path:        regexp.MustCompile(`\.env$`),
+			},
+		},
+		OrderedRules: []string{"aws-access-key", "sakura-token"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeRules(&out, listRules(cfg, nil), "table"))
+	assert.Equal(t, `ID              DESCRIPTION                                                   TAGS     KEYWORDS  ENTROPY  PATH
+aws-access-key  AWS Access Key                                                key,AWS  akia`+"               "+`
+sakura-token    `+strings.Repeat("さくらのクラウド", 7)+`さ...  cloud    sakura_   3.5      \.env$
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, writeRules(&out, listRules(cfg, []string{"aws"}), "json"))
+	assert.JSONEq(t, `[{"id": "aws-access-key", "description": "AWS Access Key", "tags": ["key", "AWS"], "keywords": ["akia"]}]`, out.String())
+
+	out.Reset()
+	require.NoError(t, writeRules(&out, listRules(cfg, []string{"unknown"}), "json"))
+	assert.JSONEq(t, `[]`, out.String())
+
+	assert.Error(t, writeRules(&out, nil, "csv"))
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{s: "short", n: 60, want: "short"},
+		{s: "a longer description", n: 10, want: "a longe..."},
+		{s: "ééééé", n: 4, want: "é..."},
+		{s: "ééééé", n: 2, want: "éé"},
+		{s: "abc", n: 0, want: ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, truncate(tt.s, tt.n))
+	}
+}
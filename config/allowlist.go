@@ -1,16 +1,45 @@
 package config
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
+)
+
+// The conditions combining the criteria of an allowlist.
+const (
+	// ConditionOR ignores a finding if any criterion matches.
+	ConditionOR = "OR"
+
+	// ConditionAND ignores a finding if all the criteria which are set
+	// match, ex: a regex only under some paths.
+	ConditionAND = "AND"
 )
 
 // Allowlist allows a rule to be ignored for specific
 // regexes, paths, and/or commits
 type Allowlist struct {
+	// Name identifies one of the named allowlists of a rule, it is empty for
+	// the allowlist of a rule and the global allowlist.
+	Name string
+
 	// Short human readable description of the allowlist.
 	Description string
 
+	// Condition is ConditionOR or ConditionAND, it is ConditionOR if
+	// empty.
+	Condition string
+
+	// Owner is who granted the allowlist and Reason why, they are included
+	// in the suppression reason of the findings it suppresses.
+	Owner  string
+	Reason string
+
+	// Expires is the last day the allowlist applies, it applies forever if
+	// it is zero.
+	Expires time.Time
+
 	// Regexes is slice of content regular expressions that are allowed to be ignored.
 	Regexes []*regexp.Regexp
 
@@ -35,6 +64,15 @@ type Allowlist struct {
 	StopWords []string
 }
 
+// MatchesAll returns true if all the criteria of the allowlist must match.
+func (a *Allowlist) MatchesAll() bool {
+	return a.Condition == ConditionAND
+}
+
+func (a *Allowlist) empty() bool {
+	return len(a.Commits)+len(a.Paths)+len(a.Regexes)+len(a.StopWords) == 0
+}
+
 // CommitAllowed returns true if the commit is allowed to be ignored.
 func (a *Allowlist) CommitAllowed(c string) bool {
 	if c == "" {
@@ -67,3 +105,42 @@ func (a *Allowlist) ContainsStopWord(s string) bool {
 	}
 	return false
 }
+
+// Expired returns true if the last day the allowlist applies is before now.
+func (a *Allowlist) Expired(now time.Time) bool {
+	return !a.Expires.IsZero() && !now.Before(a.Expires.AddDate(0, 0, 1))
+}
+
+// ExpiredAllowlists describes the global and rule allowlists of the config
+// which expired before now.
+func (c *Config) ExpiredAllowlists(now time.Time) []string {
+	var expired []string
+	describe := func(name string, a Allowlist) {
+		if !a.Expired(now) {
+			return
+		}
+		msg := fmt.Sprintf("%s expired on %s", name, a.Expires.Format("2006-01-02"))
+		if a.Owner != "" {
+			msg += ", owned by " + a.Owner
+		}
+		if a.Reason != "" {
+			msg += fmt.Sprintf(", reason %q", a.Reason)
+		}
+		expired = append(expired, msg)
+	}
+	describe("global allowlist", c.Allowlist)
+	for i, a := range c.Allowlists {
+		describe(fmt.Sprintf("global allowlist of %s", c.namedAllowlistSource("", i)), a)
+	}
+	for _, rule := range c.GetOrderedRules() {
+		describe(fmt.Sprintf("allowlist of rule %s", rule.RuleID), rule.Allowlist)
+		for i, a := range rule.Allowlists {
+			if a.Name == "" {
+				describe(fmt.Sprintf("allowlist of rule %s from %s", rule.RuleID, c.namedAllowlistSource(rule.RuleID, i)), a)
+				continue
+			}
+			describe(fmt.Sprintf("allowlist %q of rule %s", a.Name, rule.RuleID), a)
+		}
+	}
+	return expired
+}
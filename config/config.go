@@ -1,11 +1,13 @@
 package config
 
 import (
+	"bytes"
 	_ "embed"
 	"fmt"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
@@ -14,10 +16,7 @@ import (
 //go:embed gitleaks.toml
 var DefaultConfig string
 
-// use to keep track of how many configs we can extend
-// yea I know, globals bad
-var extendDepth int
-
+// maxExtendDepth is how many configs can be chained together with extend
 const maxExtendDepth = 2
 
 // ViperConfig is the config struct used by the Viper config package
@@ -29,28 +28,65 @@ type ViperConfig struct {
 	Rules       []struct {
 		ID          string
 		Description string
-		Entropy     float64
-		SecretGroup int
+		Entropy     *float64
+		SecretGroup *int
 		Regex       string
 		Keywords    []string
 		Path        string
 		Tags        []string
+		Severity    string
+		Confidence  string
+		Validator   string
+
+		TruePositives      []string
+		FalsePositives     []string
+		TruePositivePaths  map[string]string
+		FalsePositivePaths map[string]string
 
-		Allowlist struct {
-			RegexTarget string
-			Regexes     []string
-			Paths       []string
-			Commits     []string
-			StopWords   []string
+		Verify struct {
+			Method             string
+			URL                string
+			Headers            map[string]string
+			Body               string
+			SuccessStatusCodes []int
+			SuccessBodyRegex   string
 		}
+
+		Required []struct {
+			ID          string
+			Regex       string
+			SecretGroup int
+			WithinLines *int
+			WithinBytes *int
+		}
+
+		Allowlist  ViperAllowlist
+		Allowlists []ViperAllowlist
 	}
-	Allowlist struct {
-		RegexTarget string
-		Regexes     []string
-		Paths       []string
-		Commits     []string
-		StopWords   []string
-	}
+	Allowlist ViperAllowlist
+
+	EnableRules  []string
+	DisableRules []string
+	EnableTags   []string
+	DisableTags  []string
+}
+
+// ViperAllowlist is an allowlist table of the Viper config.
+type ViperAllowlist struct {
+	Name        string
+	Description string
+	Condition   string
+	RegexTarget string
+	Regexes     []string
+	Paths       []string
+	Commits     []string
+	StopWords   []string
+	Owner       string
+	Reason      string
+
+	// Expires is a YYYY-MM-DD string or a TOML local date. The TOML parser
+	// only accepts local dates followed by a space or a comment.
+	Expires interface{}
 }
 
 // Config is a configuration struct that contains rules and an allowlist if present.
@@ -62,8 +98,24 @@ type Config struct {
 	Allowlist   Allowlist
 	Keywords    []string
 
+	// Allowlists are the global allowlists of the extended configs, each
+	// applies with its own condition, owner, reason and expiry.
+	Allowlists []Allowlist
+
 	// used to keep sarif results consistent
 	OrderedRules []string
+
+	// RuleSelection selects the rules which are run.
+	RuleSelection RuleSelection
+
+	// Unselected are the rules left out by the rule selection, see
+	// SelectRules. They report no findings but still match as the required
+	// rules of the selected ones.
+	Unselected map[string]Rule
+
+	// Sources records which configs the rules and allowlist entries come
+	// from when configs are extended.
+	Sources Sources
 }
 
 // Extend is a struct that allows users to define how they want their
@@ -72,23 +124,69 @@ type Extend struct {
 	Path       string
 	URL        string
 	UseDefault bool
+
+	// DisabledRules are the IDs of the rules of the extended configs which
+	// are not added to the config.
+	DisabledRules []string
+
+	// Checksum is the SHA-256 checksum the config at URL must match.
+	Checksum string
+
+	// TTL is how long the cached copy of the config at URL is used before
+	// checking whether it changed, defaults to an hour.
+	TTL time.Duration
 }
 
+// Translate converts the Viper config to a Config, compiling its regular
+// expressions and loading the configs it extends.
 func (vc *ViperConfig) Translate() (Config, error) {
+	return vc.translate(0, nil)
+}
+
+// translate converts the Viper config of a config extended depth times, or of
+// a nested config extending parent if it is not nil. All the problems found in
+// the config and the configs it extends are returned as Errors.
+func (vc *ViperConfig) translate(depth int, parent *parentConfig) (Config, error) {
 	var (
 		keywords     []string
 		orderedRules []string
+		errs         Errors
 	)
 	rulesMap := make(map[string]Rule)
 
 	for _, r := range vc.Rules {
-		var allowlistRegexes []*regexp.Regexp
-		for _, a := range r.Allowlist.Regexes {
-			allowlistRegexes = append(allowlistRegexes, regexp.MustCompile(a))
+		allowlist := r.Allowlist.translate(r.ID, "allowlist", &errs)
+		var allowlists []Allowlist
+		names := make(map[string]bool, len(r.Allowlists))
+		for _, va := range r.Allowlists {
+			if va.Name == "" {
+				errs.add(r.ID, "allowlists.name", "", fmt.Errorf("missing, every allowlist of allowlists must be named"))
+				continue
+			}
+			if names[va.Name] {
+				errs.add(r.ID, "allowlists.name", va.Name, fmt.Errorf("duplicate allowlist name"))
+				continue
+			}
+			names[va.Name] = true
+			allowlists = append(allowlists, va.translate(r.ID, "allowlists."+va.Name, &errs))
+		}
+
+		// zero values set in the config still override the extended rule
+		overrides := ruleOverrides{
+			entropy:     r.Entropy != nil,
+			secretGroup: r.SecretGroup != nil,
+			keywords:    r.Keywords != nil,
+			tags:        r.Tags != nil,
+		}
+		var (
+			entropy     float64
+			secretGroup int
+		)
+		if r.Entropy != nil {
+			entropy = *r.Entropy
 		}
-		var allowlistPaths []*regexp.Regexp
-		for _, a := range r.Allowlist.Paths {
-			allowlistPaths = append(allowlistPaths, regexp.MustCompile(a))
+		if r.SecretGroup != nil {
+			secretGroup = *r.SecretGroup
 		}
 
 		if r.Keywords == nil {
@@ -103,81 +201,222 @@ func (vc *ViperConfig) Translate() (Config, error) {
 			r.Tags = []string{}
 		}
 
+		severity := strings.ToLower(r.Severity)
+		if _, ok := SeverityLevel(severity); severity != "" && !ok {
+			errs.add(r.ID, "severity", r.Severity, fmt.Errorf("unknown severity, must be one of %s", strings.Join(severities, ", ")))
+		}
+		confidence := strings.ToLower(r.Confidence)
+		if _, ok := ConfidenceLevel(confidence); confidence != "" && !ok {
+			errs.add(r.ID, "confidence", r.Confidence, fmt.Errorf("unknown confidence, must be one of %s", strings.Join(confidences, ", ")))
+		}
+
+		var validator *Validator
+		if r.Validator != "" {
+			var ok bool
+			if validator, ok = GetValidator(r.Validator); !ok {
+				errs.add(r.ID, "validator", r.Validator, fmt.Errorf("unknown validator"))
+			}
+		}
+
+		var verify *Verify
+		if r.Verify.URL != "" || r.Verify.Method != "" {
+			verify = &Verify{
+				Method:             r.Verify.Method,
+				URL:                r.Verify.URL,
+				Headers:            r.Verify.Headers,
+				Body:               r.Verify.Body,
+				SuccessStatusCodes: r.Verify.SuccessStatusCodes,
+			}
+			if verify.Method == "" {
+				verify.Method = "GET"
+			}
+			if r.Verify.SuccessBodyRegex != "" {
+				verify.SuccessBodyRegex = errs.compile(r.ID, "verify.successBodyRegex", r.Verify.SuccessBodyRegex)
+			}
+			verify.validate(r.ID, &errs)
+			if parent != nil {
+				errs.add(r.ID, "verify", "", fmt.Errorf("nested configs cannot set verify requests, they would send the secrets found to any url"))
+			}
+		}
+
+		var requiredRules []Required
+		for _, req := range r.Required {
+			if (req.ID == "") == (req.Regex == "") {
+				errs.add(r.ID, "required", "", fmt.Errorf("must set exactly one of id or regex"))
+				continue
+			}
+			if req.ID == r.ID {
+				errs.add(r.ID, "required.id", req.ID, fmt.Errorf("rule cannot require itself"))
+				continue
+			}
+			required := Required{
+				RuleID:      req.ID,
+				SecretGroup: req.SecretGroup,
+				WithinLines: req.WithinLines,
+				WithinBytes: req.WithinBytes,
+			}
+			if req.Regex != "" {
+				if required.Regex = errs.compile(r.ID, "required.regex", req.Regex); required.Regex == nil {
+					continue
+				}
+				if required.SecretGroup > required.Regex.NumSubexp() {
+					errs.add(r.ID, "required.secretGroup", fmt.Sprint(required.SecretGroup), fmt.Errorf("invalid secret group, max regex secret group %d", required.Regex.NumSubexp()))
+				}
+			}
+			requiredRules = append(requiredRules, required)
+		}
+
 		var configRegex *regexp.Regexp
 		var configPathRegex *regexp.Regexp
-		if r.Regex == "" {
-			configRegex = nil
-		} else {
-			configRegex = regexp.MustCompile(r.Regex)
+		if r.Regex != "" {
+			configRegex = errs.compile(r.ID, "regex", r.Regex)
 		}
-		if r.Path == "" {
-			configPathRegex = nil
-		} else {
-			configPathRegex = regexp.MustCompile(r.Path)
+		if r.Path != "" {
+			configPathRegex = errs.compile(r.ID, "path", r.Path)
 		}
 		r := Rule{
-			Description: r.Description,
-			RuleID:      r.ID,
-			Regex:       configRegex,
-			Path:        configPathRegex,
-			SecretGroup: r.SecretGroup,
-			Entropy:     r.Entropy,
-			Tags:        r.Tags,
-			Keywords:    r.Keywords,
-			Allowlist: Allowlist{
-				RegexTarget: r.Allowlist.RegexTarget,
-				Regexes:     allowlistRegexes,
-				Paths:       allowlistPaths,
-				Commits:     r.Allowlist.Commits,
-				StopWords:   r.Allowlist.StopWords,
-			},
+			Description:        r.Description,
+			RuleID:             r.ID,
+			Regex:              configRegex,
+			Path:               configPathRegex,
+			SecretGroup:        secretGroup,
+			Entropy:            entropy,
+			Tags:               r.Tags,
+			Severity:           severity,
+			Confidence:         confidence,
+			Keywords:           r.Keywords,
+			Validator:          validator,
+			Verify:             verify,
+			RequiredRules:      requiredRules,
+			TruePositives:      r.TruePositives,
+			FalsePositives:     r.FalsePositives,
+			TruePositivePaths:  r.TruePositivePaths,
+			FalsePositivePaths: r.FalsePositivePaths,
+			Allowlist:          allowlist,
+			Allowlists:         allowlists,
+			overrides:          overrides,
 		}
 		orderedRules = append(orderedRules, r.RuleID)
 
 		if r.Regex != nil && r.SecretGroup > r.Regex.NumSubexp() {
-			return Config{}, fmt.Errorf("%s invalid regex secret group %d, max regex secret group %d", r.Description, r.SecretGroup, r.Regex.NumSubexp())
+			errs.add(r.RuleID, "secretGroup", fmt.Sprint(r.SecretGroup), fmt.Errorf("invalid secret group, max regex secret group %d", r.Regex.NumSubexp()))
 		}
 		rulesMap[r.RuleID] = r
 	}
-	var allowlistRegexes []*regexp.Regexp
-	for _, a := range vc.Allowlist.Regexes {
-		allowlistRegexes = append(allowlistRegexes, regexp.MustCompile(a))
-	}
-	var allowlistPaths []*regexp.Regexp
-	for _, a := range vc.Allowlist.Paths {
-		allowlistPaths = append(allowlistPaths, regexp.MustCompile(a))
+	if vc.Allowlist.Name != "" {
+		errs.add("", "allowlist.name", vc.Allowlist.Name, fmt.Errorf("only the allowlists of rules can be named"))
 	}
 	c := Config{
-		Description: vc.Description,
-		Extend:      vc.Extend,
-		Rules:       rulesMap,
-		Allowlist: Allowlist{
-			RegexTarget: vc.Allowlist.RegexTarget,
-			Regexes:     allowlistRegexes,
-			Paths:       allowlistPaths,
-			Commits:     vc.Allowlist.Commits,
-			StopWords:   vc.Allowlist.StopWords,
-		},
+		Description:  vc.Description,
+		Extend:       vc.Extend,
+		Rules:        rulesMap,
+		Allowlist:    vc.Allowlist.translate("", "allowlist", &errs),
 		Keywords:     keywords,
 		OrderedRules: orderedRules,
+		RuleSelection: RuleSelection{
+			EnableRules:  vc.EnableRules,
+			DisableRules: vc.DisableRules,
+			EnableTags:   vc.EnableTags,
+			DisableTags:  vc.DisableTags,
+		},
 	}
+	c.Sources = newSources(&c)
+	c.RuleSelection.validate(&errs)
 
-	if maxExtendDepth != extendDepth {
-		// disallow both usedefault and path from being set
-		if c.Extend.Path != "" && c.Extend.UseDefault {
-			log.Fatal().Msg("unable to load config due to extend.path and extend.useDefault being set")
+	if parent != nil {
+		if c.Extend.Path != "" || c.Extend.URL != "" || c.Extend.UseDefault {
+			errs.add("", "extend", "", fmt.Errorf("nested configs extend the config of their parent directory, only disabledRules can be set"))
+		}
+		log.Debug().Msgf("extending nested config with %s", parent.source)
+		// the nested config selects its rules among all the rules of parent
+		c.extend(parent.config.withUnselected(), parent.source)
+	} else if depth < maxExtendDepth {
+		// disallow more than one of usedefault, path and url from being set
+		switch {
+		case c.Extend.Path != "" && c.Extend.UseDefault:
+			errs.add("", "extend", "", fmt.Errorf("only one of path, url and useDefault can be set, got path and useDefault"))
+		case c.Extend.URL != "" && (c.Extend.Path != "" || c.Extend.UseDefault):
+			errs.add("", "extend", "", fmt.Errorf("only one of path, url and useDefault can be set, got url and path or useDefault"))
+		case c.Extend.UseDefault:
+			if err := c.extendDefault(depth + 1); err != nil {
+				errs.addExtended("default config", "extend.useDefault", err)
+			}
+		case c.Extend.Path != "":
+			if err := c.extendPath(depth + 1); err != nil {
+				errs.addExtended(c.Extend.Path, "extend.path", err)
+			}
+		case c.Extend.URL != "":
+			if err := c.extendURL(depth + 1); err != nil {
+				errs.addExtended(c.Extend.URL, "extend.url", err)
+			}
 		}
-		if c.Extend.UseDefault {
-			c.extendDefault()
-		} else if c.Extend.Path != "" {
-			c.extendPath()
+	}
+
+	for _, ruleID := range c.OrderedRules {
+		rule := c.Rules[ruleID]
+		// a rule overriding an extended rule only gets its regex or path
+		// once extended
+		if rule.Regex == nil && rule.Path == nil {
+			errs.add(ruleID, "regex", "", fmt.Errorf("missing, the rule has no regex or path and overrides no rule of the extended configs"))
 		}
+		rule.overrides = ruleOverrides{}
+		c.Rules[ruleID] = rule
+	}
 
+	// required rules may be defined by an extended config
+	for _, ruleID := range c.OrderedRules {
+		for _, req := range c.Rules[ruleID].RequiredRules {
+			if req.RuleID == "" {
+				continue
+			}
+			if _, ok := c.Rules[req.RuleID]; !ok {
+				errs.add(ruleID, "required.id", req.RuleID, fmt.Errorf("required rule does not exist"))
+			}
+		}
 	}
 
+	if err := errs.err(); err != nil {
+		return Config{}, err
+	}
 	return c, nil
 }
 
+// translate converts the allowlist table field of the rule ruleID, or of the
+// config if ruleID is empty, adding its problems to errs.
+func (va ViperAllowlist) translate(ruleID, field string, errs *Errors) Allowlist {
+	a := Allowlist{
+		Name:        va.Name,
+		Description: va.Description,
+		Condition:   strings.ToUpper(va.Condition),
+		RegexTarget: va.RegexTarget,
+		Regexes:     errs.compileAll(ruleID, field+".regexes", va.Regexes),
+		Paths:       errs.compileAll(ruleID, field+".paths", va.Paths),
+		Commits:     va.Commits,
+		StopWords:   va.StopWords,
+		Owner:       va.Owner,
+		Reason:      va.Reason,
+	}
+	if !validRegexTarget(va.RegexTarget) {
+		errs.add(ruleID, field+".regexTarget", va.RegexTarget, fmt.Errorf("unknown regex target, must be one of match, line"))
+	}
+	if a.Condition != "" && a.Condition != ConditionOR && a.Condition != ConditionAND {
+		errs.add(ruleID, field+".condition", va.Condition, fmt.Errorf("unknown condition, must be one of OR, AND"))
+	}
+	if va.Expires != nil {
+		// TOML local dates are printed as YYYY-MM-DD
+		expires, err := time.Parse("2006-01-02", fmt.Sprint(va.Expires))
+		if err != nil {
+			errs.add(ruleID, field+".expires", fmt.Sprint(va.Expires), fmt.Errorf("must be a YYYY-MM-DD date"))
+		}
+		a.Expires = expires
+	}
+	return a
+}
+
+func validRegexTarget(target string) bool {
+	return target == "" || target == "match" || target == "line"
+}
+
 func (c *Config) GetOrderedRules() []Rule {
 	var orderedRules []Rule
 	for _, id := range c.OrderedRules {
@@ -188,71 +427,186 @@ func (c *Config) GetOrderedRules() []Rule {
 	return orderedRules
 }
 
-func (c *Config) extendDefault() {
-	extendDepth++
-	viper.SetConfigType("toml")
-	if err := viper.ReadConfig(strings.NewReader(DefaultConfig)); err != nil {
-		log.Fatal().Msgf("failed to load extended config, err: %s", err)
-		return
+func (c *Config) extendDefault(depth int) error {
+	v := viper.New()
+	v.SetConfigType("toml")
+	if err := v.ReadConfig(strings.NewReader(DefaultConfig)); err != nil {
+		return err
 	}
 	defaultViperConfig := ViperConfig{}
-	if err := viper.Unmarshal(&defaultViperConfig); err != nil {
-		log.Fatal().Msgf("failed to load extended config, err: %s", err)
-		return
+	if err := v.Unmarshal(&defaultViperConfig); err != nil {
+		return err
 	}
-	cfg, err := defaultViperConfig.Translate()
+	cfg, err := defaultViperConfig.translate(depth, nil)
 	if err != nil {
-		log.Fatal().Msgf("failed to load extended config, err: %s", err)
-		return
+		return err
 	}
 	log.Debug().Msg("extending config with default config")
-	c.extend(cfg)
-
+	c.extend(cfg, DefaultSource)
+	return nil
 }
 
-func (c *Config) extendPath() {
-	extendDepth++
-	viper.SetConfigFile(c.Extend.Path)
-	if err := viper.ReadInConfig(); err != nil {
-		log.Fatal().Msgf("failed to load extended config, err: %s", err)
-		return
+func (c *Config) extendPath(depth int) error {
+	v := viper.New()
+	v.SetConfigFile(c.Extend.Path)
+	if err := v.ReadInConfig(); err != nil {
+		return err
 	}
 	extensionViperConfig := ViperConfig{}
-	if err := viper.Unmarshal(&extensionViperConfig); err != nil {
-		log.Fatal().Msgf("failed to load extended config, err: %s", err)
-		return
+	if err := v.Unmarshal(&extensionViperConfig); err != nil {
+		return err
 	}
-	cfg, err := extensionViperConfig.Translate()
+	cfg, err := extensionViperConfig.translate(depth, nil)
 	if err != nil {
-		log.Fatal().Msgf("failed to load extended config, err: %s", err)
-		return
+		return err
 	}
 	log.Debug().Msgf("extending config with %s", c.Extend.Path)
-	c.extend(cfg)
+	c.extend(cfg, c.Extend.Path)
+	return nil
 }
 
-func (c *Config) extendURL() {
-	// TODO
+func (c *Config) extendURL(depth int) error {
+	data, err := fetchExtendURL(c.Extend)
+	if err != nil {
+		return err
+	}
+	v := viper.New()
+	v.SetConfigType("toml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return err
+	}
+	extensionViperConfig := ViperConfig{}
+	if err := v.Unmarshal(&extensionViperConfig); err != nil {
+		return err
+	}
+	// a remote config is not reviewed along with the config extending it
+	for _, r := range extensionViperConfig.Rules {
+		if r.Verify.URL != "" || r.Verify.Method != "" {
+			return fmt.Errorf("%s: configs extended from a url cannot set verify requests, they would send the secrets found to any url", r.ID)
+		}
+	}
+	cfg, err := extensionViperConfig.translate(depth, nil)
+	if err != nil {
+		return err
+	}
+	log.Debug().Msgf("extending config with %s", c.Extend.URL)
+	c.extend(cfg, c.Extend.URL)
+	return nil
 }
 
-func (c *Config) extend(extensionConfig Config) {
+// extend adds the rules and allowlists of extensionConfig, loaded from
+// source, to the config. Rules of the config take precedence: a rule with the
+// same ID as a rule of extensionConfig overrides the fields it sets, see
+// mergeRule. The allowlists of extensionConfig are kept apart from the
+// allowlists of the config.
+func (c *Config) extend(extensionConfig Config, source string) {
+	extensionSources := extensionConfig.Sources.extended(source)
+	disabled := make(map[string]bool, len(c.Extend.DisabledRules))
+	for _, ruleID := range c.Extend.DisabledRules {
+		disabled[ruleID] = true
+	}
 	for ruleID, rule := range extensionConfig.Rules {
-		if _, ok := c.Rules[ruleID]; !ok {
-			log.Trace().Msgf("adding %s to base config", ruleID)
-			c.Rules[ruleID] = rule
+		if disabled[ruleID] {
+			log.Trace().Msgf("disabling %s", ruleID)
+			continue
+		}
+		if baseRule, ok := c.Rules[ruleID]; ok {
+			log.Trace().Msgf("overriding %s with base config", ruleID)
+			c.Rules[ruleID] = mergeRule(baseRule, rule)
 			c.Keywords = append(c.Keywords, rule.Keywords...)
-			c.OrderedRules = append(c.OrderedRules, ruleID)
+			c.Sources.Rules[ruleID] = append(extensionSources.Rules[ruleID], c.Sources.Rules[ruleID]...)
+			var allowlistSources []string
+			if !rule.Allowlist.empty() {
+				allowlistSources = append(allowlistSources, allowlistSource(extensionSources.Allowlists[ruleID], source))
+			}
+			allowlistSources = append(allowlistSources, extensionSources.NamedAllowlists[ruleID]...)
+			c.Sources.NamedAllowlists[ruleID] = append(allowlistSources, c.Sources.NamedAllowlists[ruleID]...)
+			continue
 		}
+		log.Trace().Msgf("adding %s to base config", ruleID)
+		c.Rules[ruleID] = rule
+		c.Keywords = append(c.Keywords, rule.Keywords...)
+		c.OrderedRules = append(c.OrderedRules, ruleID)
+		c.Sources.Rules[ruleID] = extensionSources.Rules[ruleID]
+		c.Sources.Allowlists[ruleID] = extensionSources.Allowlists[ruleID]
+		c.Sources.NamedAllowlists[ruleID] = extensionSources.NamedAllowlists[ruleID]
 	}
 
 	// append allowlists, not attempting to merge
-	c.Allowlist.Commits = append(c.Allowlist.Commits,
-		extensionConfig.Allowlist.Commits...)
-	c.Allowlist.Paths = append(c.Allowlist.Paths,
-		extensionConfig.Allowlist.Paths...)
-	c.Allowlist.Regexes = append(c.Allowlist.Regexes,
-		extensionConfig.Allowlist.Regexes...)
+	if !extensionConfig.Allowlist.empty() {
+		c.Allowlists = append(c.Allowlists, extensionConfig.Allowlist)
+		c.Sources.NamedAllowlists[""] = append(c.Sources.NamedAllowlists[""], source)
+	}
+	c.Allowlists = append(c.Allowlists, extensionConfig.Allowlists...)
+	c.Sources.NamedAllowlists[""] = append(c.Sources.NamedAllowlists[""], extensionSources.NamedAllowlists[""]...)
+	c.RuleSelection = c.RuleSelection.inherit(extensionConfig.RuleSelection)
 
 	// sort to keep extended rules in order
 	sort.Strings(c.OrderedRules)
 }
+
+// mergeRule overrides the fields of an extended rule with the fields set by
+// the base rule. Fields the base rule leaves unset are inherited from the
+// extended rule. Entropy, secretGroup, keywords and tags are set even when
+// set to zero or an empty array, so the base rule can reset them. The
+// allowlists of both rules are kept apart: the allowlist of the extended rule
+// becomes one of the allowlists of the merged rule.
+func mergeRule(base, extended Rule) Rule {
+	merged := extended
+	if base.Description != "" {
+		merged.Description = base.Description
+	}
+	if base.Regex != nil {
+		merged.Regex = base.Regex
+	}
+	if base.Path != nil {
+		merged.Path = base.Path
+	}
+	if base.overrides.secretGroup {
+		merged.SecretGroup = base.SecretGroup
+	}
+	if base.overrides.entropy {
+		merged.Entropy = base.Entropy
+	}
+	if base.overrides.keywords {
+		merged.Keywords = base.Keywords
+	}
+	if base.overrides.tags {
+		merged.Tags = base.Tags
+	}
+	if base.Severity != "" {
+		merged.Severity = base.Severity
+	}
+	if base.Confidence != "" {
+		merged.Confidence = base.Confidence
+	}
+	if base.Validator != nil {
+		merged.Validator = base.Validator
+	}
+	if base.Verify != nil {
+		merged.Verify = base.Verify
+	}
+	if len(base.RequiredRules) > 0 {
+		merged.RequiredRules = base.RequiredRules
+	}
+	if len(base.TruePositives) > 0 {
+		merged.TruePositives = base.TruePositives
+	}
+	if len(base.FalsePositives) > 0 {
+		merged.FalsePositives = base.FalsePositives
+	}
+	if len(base.TruePositivePaths) > 0 {
+		merged.TruePositivePaths = base.TruePositivePaths
+	}
+	if len(base.FalsePositivePaths) > 0 {
+		merged.FalsePositivePaths = base.FalsePositivePaths
+	}
+
+	merged.Allowlist = base.Allowlist
+	var allowlists []Allowlist
+	if !extended.Allowlist.empty() {
+		allowlists = append(allowlists, extended.Allowlist)
+	}
+	merged.Allowlists = append(append(allowlists, extended.Allowlists...), base.Allowlists...)
+	return merged
+}
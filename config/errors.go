@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Error is a problem with a field of a config.
+type Error struct {
+	// Source is the path or URL of the extended config with the problem,
+	// empty for the config being translated.
+	Source string
+
+	// RuleID is the ID of the rule with the problem, empty for the global
+	// tables of the config.
+	RuleID string
+
+	// Field is the TOML key of the field with the problem, ex: regex or
+	// allowlist.paths.
+	Field string
+
+	// Value is the offending value, if any.
+	Value string
+
+	// Err describes the problem.
+	Err error
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	for _, prefix := range []string{e.Source, e.RuleID} {
+		if prefix != "" {
+			sb.WriteString(prefix + ": ")
+		}
+	}
+	if e.Field != "" {
+		sb.WriteString(e.Field)
+		if e.Value != "" {
+			fmt.Fprintf(&sb, " %q", e.Value)
+		}
+		sb.WriteString(": ")
+	}
+	sb.WriteString(e.Err.Error())
+	return sb.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errors are all the problems found in a config.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d problems found in config:", len(e))
+	for _, err := range e {
+		sb.WriteString("\n  " + err.Error())
+	}
+	return sb.String()
+}
+
+// add records a problem with a field of the rule ruleID.
+func (e *Errors) add(ruleID, field, value string, err error) {
+	*e = append(*e, &Error{RuleID: ruleID, Field: field, Value: value, Err: err})
+}
+
+// addExtended records the problems of the extended config source, or err if
+// it is not a list of problems.
+func (e *Errors) addExtended(source, field string, err error) {
+	if errs, ok := err.(Errors); ok {
+		for _, extendedErr := range errs {
+			if extendedErr.Source == "" {
+				extendedErr.Source = source
+			}
+			*e = append(*e, extendedErr)
+		}
+		return
+	}
+	*e = append(*e, &Error{Field: field, Value: source, Err: err})
+}
+
+// compile compiles the regular expression of a field of the rule ruleID,
+// returning nil and recording the problem if it is invalid.
+func (e *Errors) compile(ruleID, field, expr string) *regexp.Regexp {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		e.add(ruleID, field, expr, err)
+		return nil
+	}
+	return re
+}
+
+// compileAll compiles the regular expressions of a field of the rule ruleID,
+// skipping and recording the invalid ones.
+func (e *Errors) compileAll(ruleID, field string, exprs []string) []*regexp.Regexp {
+	var res []*regexp.Regexp
+	for _, expr := range exprs {
+		if re := e.compile(ruleID, field, expr); re != nil {
+			res = append(res, re)
+		}
+	}
+	return res
+}
+
+// err returns the recorded problems, or nil if there are none.
+func (e Errors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,17 @@
+package config
+
+// parentConfig is the config a nested config extends and the source it is
+// loaded from.
+type parentConfig struct {
+	config Config
+	source string
+}
+
+// TranslateNested converts the Viper config of a .gitleaks.toml file found in
+// a subdirectory of the source being scanned. The nested config extends
+// parent, the config of the parent directory loaded from parentSource: its
+// rules override the rules of parent, see Translate. Nested configs can drop
+// rules of parent with extend.disabledRules but cannot extend other configs.
+func (vc *ViperConfig) TranslateNested(parent Config, parentSource string) (Config, error) {
+	return vc.translate(maxExtendDepth, &parentConfig{config: parent, source: parentSource})
+}
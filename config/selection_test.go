@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelect(t *testing.T) {
+	rules := map[string]Rule{
+		"aws-access-token":  {RuleID: "aws-access-token", Tags: []string{"aws", "cloud"}},
+		"github-pat":        {RuleID: "github-pat", Tags: []string{"github", "vcs"}},
+		"gitlab-pat":        {RuleID: "gitlab-pat", Tags: []string{"gitlab", "vcs"}},
+		"generic-api-key":   {RuleID: "generic-api-key"},
+		"stripe-access-key": {RuleID: "stripe-access-key", Tags: []string{"stripe", "payment"}},
+	}
+
+	tests := []struct {
+		name      string
+		selection RuleSelection
+		expected  []string
+		unmatched []string
+	}{
+		{
+			name:     "empty selection",
+			expected: []string{"aws-access-token", "generic-api-key", "github-pat", "gitlab-pat", "stripe-access-key"},
+		},
+		{
+			name:      "enable rules",
+			selection: RuleSelection{EnableRules: []string{"github-pat", "unknown"}},
+			expected:  []string{"github-pat"},
+			unmatched: []string{"unknown"},
+		},
+		{
+			name:      "enable rule glob and tag",
+			selection: RuleSelection{EnableRules: []string{"git*-pat"}, EnableTags: []string{"Payment"}},
+			expected:  []string{"github-pat", "gitlab-pat", "stripe-access-key"},
+		},
+		{
+			name:      "disable rule glob",
+			selection: RuleSelection{DisableRules: []string{"generic-*"}},
+			expected:  []string{"aws-access-token", "github-pat", "gitlab-pat", "stripe-access-key"},
+		},
+		{
+			name:      "disable tag of enabled rules",
+			selection: RuleSelection{EnableTags: []string{"vcs", "cloud"}, DisableTags: []string{"gitlab"}},
+			expected:  []string{"aws-access-token", "github-pat"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, unmatched := tt.selection.Select(rules)
+			var ids []string
+			for ruleID := range selected {
+				ids = append(ids, ruleID)
+			}
+			sort.Strings(ids)
+			assert.Equal(t, tt.expected, ids)
+			assert.Equal(t, tt.unmatched, unmatched)
+		})
+	}
+}
+
+func TestTranslateRuleSelection(t *testing.T) {
+	tests := []struct {
+		cfgName   string
+		selection RuleSelection
+		wantError error
+	}{
+		{
+			cfgName: "selection",
+			selection: RuleSelection{
+				EnableTags:   []string{"aws", "vcs"},
+				DisableRules: []string{"aws-mws-*"},
+			},
+		},
+		{
+			cfgName: "bad_selection",
+			wantError: Errors{
+				{Field: "enableRules", Value: "aws-[access-key", Err: fmt.Errorf("invalid glob pattern")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cfgName, func(t *testing.T) {
+			viper.Reset()
+			viper.AddConfigPath(configPath)
+			viper.SetConfigName(tt.cfgName)
+			viper.SetConfigType("toml")
+			require.NoError(t, viper.ReadInConfig())
+			var vc ViperConfig
+			require.NoError(t, viper.Unmarshal(&vc))
+			cfg, err := vc.Translate()
+			assert.Equal(t, tt.wantError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.selection, cfg.RuleSelection)
+
+			selected, _ := cfg.RuleSelection.Select(cfg.Rules)
+			assert.Contains(t, selected, "github-pat")
+			assert.Contains(t, selected, "aws-access-key")
+			assert.NotContains(t, selected, "aws-mws-key")
+			assert.NotContains(t, selected, "facebook-secret-key")
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import "strings"
+
+// Severities of rules, from the least to the most severe.
+const (
+	SeverityInfo     = "info"
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// Confidences of rules, i.e. how likely a match is to be an actual secret.
+const (
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+var (
+	severities  = []string{SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+	confidences = []string{ConfidenceLow, ConfidenceMedium, ConfidenceHigh}
+)
+
+// SeverityLevel returns the position of severity in the list of severities,
+// higher is more severe. ok is false if the severity is unknown.
+func SeverityLevel(severity string) (level int, ok bool) {
+	return indexOf(severities, strings.ToLower(severity))
+}
+
+// ConfidenceLevel returns the position of confidence in the list of
+// confidences, higher is more confident. ok is false if the confidence is
+// unknown.
+func ConfidenceLevel(confidence string) (level int, ok bool) {
+	return indexOf(confidences, strings.ToLower(confidence))
+}
+
+func indexOf(values []string, value string) (int, bool) {
+	for i, v := range values {
+		if v == value {
+			return i, true
+		}
+	}
+	return -1, false
+}
@@ -0,0 +1,132 @@
+package config
+
+// DefaultSource is the source of the rules and allowlist entries of the
+// default config.
+const DefaultSource = "default config"
+
+// Sources records the configs the rules and allowlist entries of a config
+// come from. Extended configs are identified by their path or URL, or
+// DefaultSource, and the config being translated by an empty string.
+type Sources struct {
+	// Rules are the configs defining each rule, by rule ID. The first config
+	// defines the rule and the next ones override some of its fields.
+	Rules map[string][]string
+
+	// Allowlists are the configs the entries of each rule allowlist come
+	// from, by rule ID. The global allowlist has an empty ID.
+	Allowlists map[string][]AllowlistSource
+
+	// NamedAllowlists are the configs each allowlist of Rule.Allowlists
+	// comes from, by rule ID, in the same order. The allowlists of
+	// Config.Allowlists have an empty ID.
+	NamedAllowlists map[string][]string
+}
+
+// AllowlistSource is a config consecutive entries of an allowlist come from.
+// The number of entries of each field coming from Source follow the entries
+// of the previous AllowlistSource.
+type AllowlistSource struct {
+	Source    string
+	Regexes   int
+	Paths     int
+	Commits   int
+	StopWords int
+}
+
+// EntrySources returns the source of each entry of the allowlist of the rule
+// ruleID, by field: regexes, paths, commits and stopwords.
+func (s Sources) EntrySources(ruleID string) map[string][]string {
+	entries := make(map[string][]string)
+	for _, as := range s.Allowlists[ruleID] {
+		for field, n := range map[string]int{"regexes": as.Regexes, "paths": as.Paths, "commits": as.Commits, "stopwords": as.StopWords} {
+			for i := 0; i < n; i++ {
+				entries[field] = append(entries[field], as.Source)
+			}
+		}
+	}
+	return entries
+}
+
+// newSources returns the sources of the config being translated.
+func newSources(c *Config) Sources {
+	s := Sources{
+		Rules:           make(map[string][]string, len(c.Rules)),
+		Allowlists:      make(map[string][]AllowlistSource),
+		NamedAllowlists: make(map[string][]string),
+	}
+	for ruleID, rule := range c.Rules {
+		s.Rules[ruleID] = []string{""}
+		s.Allowlists[ruleID] = allowlistSources("", rule.Allowlist)
+		for range rule.Allowlists {
+			s.NamedAllowlists[ruleID] = append(s.NamedAllowlists[ruleID], "")
+		}
+	}
+	s.Allowlists[""] = allowlistSources("", c.Allowlist)
+	return s
+}
+
+func allowlistSources(source string, a Allowlist) []AllowlistSource {
+	as := AllowlistSource{
+		Source:    source,
+		Regexes:   len(a.Regexes),
+		Paths:     len(a.Paths),
+		Commits:   len(a.Commits),
+		StopWords: len(a.StopWords),
+	}
+	if as.Regexes+as.Paths+as.Commits+as.StopWords == 0 {
+		return nil
+	}
+	return []AllowlistSource{as}
+}
+
+// namedAllowlistSource returns the config the i-th allowlist of
+// Rule.Allowlists of the rule ruleID, or of Config.Allowlists if ruleID is
+// empty, comes from.
+func (c *Config) namedAllowlistSource(ruleID string, i int) string {
+	if sources := c.Sources.NamedAllowlists[ruleID]; i < len(sources) && sources[i] != "" {
+		return sources[i]
+	}
+	return "the config"
+}
+
+// allowlistSource returns the config the entries of an allowlist, recorded
+// by sources, come from, or source if it has no entries.
+func allowlistSource(sources []AllowlistSource, source string) string {
+	if len(sources) > 0 {
+		return sources[0].Source
+	}
+	return source
+}
+
+// extended returns the sources of a config extended as source, i.e. its own
+// rules and allowlist entries come from source.
+func (s Sources) extended(source string) Sources {
+	relabel := func(from string) string {
+		if from == "" {
+			return source
+		}
+		return from
+	}
+	extended := Sources{
+		Rules:           make(map[string][]string, len(s.Rules)),
+		Allowlists:      make(map[string][]AllowlistSource, len(s.Allowlists)),
+		NamedAllowlists: make(map[string][]string, len(s.NamedAllowlists)),
+	}
+	for ruleID, sources := range s.Rules {
+		for _, from := range sources {
+			extended.Rules[ruleID] = append(extended.Rules[ruleID], relabel(from))
+		}
+	}
+	for ruleID, sources := range s.Allowlists {
+		for _, as := range sources {
+			as.Source = relabel(as.Source)
+			extended.Allowlists[ruleID] = append(extended.Allowlists[ruleID], as)
+		}
+	}
+	for ruleID, sources := range s.NamedAllowlists {
+		for _, from := range sources {
+			extended.NamedAllowlists[ruleID] = append(extended.NamedAllowlists[ruleID], relabel(from))
+		}
+	}
+	return extended
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// Validate returns all the problems found in the Viper config: the problems
+// preventing it from being translated and the ones making its rules behave
+// unexpectedly, ex: keywords which can never be part of a match of the rule's
+// regex while other keywords can. It returns nil if the config is valid.
+func (vc *ViperConfig) Validate() error {
+	var errs Errors
+	if _, err := vc.Translate(); err != nil {
+		if translateErrs, ok := err.(Errors); ok {
+			errs = append(errs, translateErrs...)
+		} else {
+			errs.add("", "", "", err)
+		}
+	}
+
+	ruleIDs := make(map[string]bool, len(vc.Rules))
+	for _, r := range vc.Rules {
+		if r.ID == "" {
+			errs.add("", "id", "", fmt.Errorf("missing for rule %q, rules without an id replace each other", r.Description))
+		} else if ruleIDs[r.ID] {
+			errs.add(r.ID, "id", "", fmt.Errorf("duplicate rule id, the rule replaces the previous rule with the same id"))
+		}
+		ruleIDs[r.ID] = true
+
+		if r.Regex == "" {
+			continue
+		}
+		re, err := syntax.Parse(r.Regex, syntax.Perl)
+		if err != nil {
+			// reported by Translate
+			continue
+		}
+		prog, err := syntax.Compile(re.Simplify())
+		if err != nil {
+			continue
+		}
+		// keywords outside of every match are only a problem if some keywords
+		// are part of the matches, otherwise they are the context in which
+		// the rule applies, ex: twilio for SK[0-9a-fA-F]{32}
+		var unmatched []string
+		for _, k := range r.Keywords {
+			if !canContain(prog, strings.ToLower(k)) {
+				unmatched = append(unmatched, k)
+			}
+		}
+		if len(unmatched) == len(r.Keywords) {
+			continue
+		}
+		for _, k := range unmatched {
+			errs.add(r.ID, "keywords", k, fmt.Errorf("keyword can never be part of a match of the rule's regex"))
+		}
+	}
+	return errs.err()
+}
+
+// canContain reports whether a match of prog may contain s, ignoring case.
+// It follows every path of the program consuming the runes of s from any
+// instruction, so it may report true for matches which are impossible, but
+// never reports false for possible ones.
+func canContain(prog *syntax.Prog, s string) bool {
+	current := make(map[uint32]bool, len(prog.Inst))
+	for pc := range prog.Inst {
+		current[uint32(pc)] = true
+	}
+	for _, r := range s {
+		next := make(map[uint32]bool)
+		for pc := range closure(prog, current) {
+			inst := prog.Inst[pc]
+			switch inst.Op {
+			case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+				if inst.MatchRune(r) || inst.MatchRune(unicode.ToUpper(r)) {
+					next[inst.Out] = true
+				}
+			}
+		}
+		if len(next) == 0 {
+			return false
+		}
+		current = next
+	}
+	return true
+}
+
+// closure returns the instructions reachable from pcs without consuming a
+// rune.
+func closure(prog *syntax.Prog, pcs map[uint32]bool) map[uint32]bool {
+	reachable := make(map[uint32]bool, len(pcs))
+	var stack []uint32
+	for pc := range pcs {
+		stack = append(stack, pc)
+	}
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if reachable[pc] {
+			continue
+		}
+		reachable[pc] = true
+		inst := prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Out, inst.Arg)
+		case syntax.InstCapture, syntax.InstEmptyWidth, syntax.InstNop:
+			stack = append(stack, inst.Out)
+		}
+	}
+	return reachable
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	viper.Reset()
+	viper.AddConfigPath(configPath)
+	viper.SetConfigName("bad_config")
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadInConfig())
+
+	var vc ViperConfig
+	require.NoError(t, viper.Unmarshal(&vc))
+	err := vc.Validate()
+	require.IsType(t, Errors{}, err)
+	errs := err.(Errors)
+	assert.Len(t, errs, 6)
+	assert.Equal(t, &Error{RuleID: "unclosed-group", Field: "keywords", Value: "xyz", Err: fmt.Errorf("keyword can never be part of a match of the rule's regex")}, errs[5])
+	assert.Equal(t, `6 problems found in config:
+  unclosed-group: allowlist.paths "[z-a]": error parsing regexp: invalid character class range: `+"`z-a`"+`
+  unclosed-group: allowlist.regexTarget "secret": unknown regex target, must be one of match, line
+  unclosed-group: regex "(abc": error parsing regexp: missing closing ): `+"`(abc`"+`
+  allowlist.regexes "*": error parsing regexp: missing argument to repetition operator: `+"`*`"+`
+  unclosed-group: id: duplicate rule id, the rule replaces the previous rule with the same id
+  unclosed-group: keywords "xyz": keyword can never be part of a match of the rule's regex`, err.Error())
+
+	viper.Reset()
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(DefaultConfig)))
+	vc = ViperConfig{}
+	require.NoError(t, viper.Unmarshal(&vc))
+	assert.NoError(t, vc.Validate())
+}
+
+func TestCanContain(t *testing.T) {
+	tests := []struct {
+		regex   string
+		keyword string
+		want    bool
+	}{
+		{regex: `ghp_[0-9a-zA-Z]{36}`, keyword: "ghp_", want: true},
+		{regex: `(?:A3T[A-Z0-9]|AKIA|ASIA)[A-Z0-9]{16}`, keyword: "akia", want: true},
+		{regex: `(?i)(?:adafruit)(?:[0-9a-z\-_\t .]{0,20})`, keyword: "adafruit", want: true},
+		{regex: `sk_(?:live|test)_[0-9a-z]{24}`, keyword: "sk_live", want: true},
+		{regex: `[a-z]+`, keyword: "anything", want: true},
+		{regex: `SK[0-9a-fA-F]{32}`, keyword: "twilio", want: false},
+		{regex: `\b(v1\.0-[a-f0-9]{24})`, keyword: "cloudflare", want: false},
+		{regex: `abc`, keyword: "abcd", want: false},
+	}
+	for _, tt := range tests {
+		re, err := syntax.Parse(tt.regex, syntax.Perl)
+		require.NoError(t, err)
+		prog, err := syntax.Compile(re.Simplify())
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, canContain(prog, tt.keyword), "%s in %s", tt.keyword, tt.regex)
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"hash/crc32"
+	"strings"
+	"sync"
+)
+
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Validator performs structural checks on a secret, ex: verifying an embedded
+// checksum. Validators do not have network access.
+type Validator struct {
+	// Name is used to reference the validator from a config file.
+	Name string
+
+	// Validate returns true if the secret is structurally valid.
+	Validate func(secret string) bool
+}
+
+var (
+	validatorsMutex sync.RWMutex
+	validators      = map[string]*Validator{}
+)
+
+func init() {
+	RegisterValidator(&Validator{
+		Name:     "crc32-base62",
+		Validate: validCRC32Base62,
+	})
+}
+
+// RegisterValidator makes a validator available to config files. Registering
+// a validator with the name of an existing one replaces it.
+func RegisterValidator(v *Validator) {
+	validatorsMutex.Lock()
+	defer validatorsMutex.Unlock()
+	validators[v.Name] = v
+}
+
+// GetValidator returns the registered validator with the given name.
+func GetValidator(name string) (*Validator, bool) {
+	validatorsMutex.RLock()
+	defer validatorsMutex.RUnlock()
+	v, ok := validators[name]
+	return v, ok
+}
+
+// CRC32Base62 returns the checksum embedded in GitHub and npm tokens: the
+// CRC32 of s encoded in base62 and left padded to six characters.
+func CRC32Base62(s string) string {
+	n := crc32.ChecksumIEEE([]byte(s))
+	checksum := make([]byte, 6)
+	for i := len(checksum) - 1; i >= 0; i-- {
+		checksum[i] = base62Alphabet[n%62]
+		n /= 62
+	}
+	return string(checksum)
+}
+
+// validCRC32Base62 validates tokens in the format `prefix_<random><checksum>`
+// where checksum is the CRC32Base62 of the random part.
+func validCRC32Base62(secret string) bool {
+	token := secret[strings.LastIndex(secret, "_")+1:]
+	if len(token) <= 6 {
+		return false
+	}
+	return CRC32Base62(token[:len(token)-6]) == token[len(token)-6:]
+}
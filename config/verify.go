@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"text/template"
+)
+
+// Verify describes the HTTP request used to check whether a secret found by
+// a rule is active. URL, Headers and Body are text/template templates which
+// are executed with the secret, the match, the named capture groups of the
+// rule's regex and the secrets of the rule's required rules:
+//
+//	{{ .Secret }} {{ .Match }} {{ .Groups.name }} {{ index .Required "rule-id" }}
+type Verify struct {
+	// Method is the HTTP method of the request, defaults to GET.
+	Method string
+
+	// URL is the templated URL of the request.
+	URL string
+
+	// Headers are the templated headers of the request.
+	Headers map[string]string
+
+	// Body is the templated body of the request.
+	Body string
+
+	// SuccessStatusCodes are the response status codes meaning that the
+	// secret is active. Any 2xx status code is accepted if none are set.
+	SuccessStatusCodes []int
+
+	// SuccessBodyRegex must match the response body for the secret to be
+	// considered active, if it is set.
+	SuccessBodyRegex *regexp.Regexp
+}
+
+// validate records a problem for each template of the request of the rule
+// ruleID which cannot be parsed.
+func (v *Verify) validate(ruleID string, errs *Errors) {
+	if v.URL == "" {
+		errs.add(ruleID, "verify.url", "", fmt.Errorf("is required"))
+	}
+	templates := map[string]string{"url": v.URL, "body": v.Body}
+	for name, value := range v.Headers {
+		templates["headers."+name] = value
+	}
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := template.New(name).Option("missingkey=error").Parse(templates[name]); err != nil {
+			errs.add(ruleID, "verify."+name, templates[name], fmt.Errorf("invalid template: %s", err))
+		}
+	}
+}
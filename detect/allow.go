@@ -0,0 +1,162 @@
+package detect
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	allowNextLine   = "gitleaks:allow-next-line"
+	allowBlockBegin = "gitleaks:allow-begin"
+	allowBlockEnd   = "gitleaks:allow-end"
+)
+
+var (
+	// inlineAllowRegex matches the gitleaks:allow comments. A bare
+	// gitleaks:allow anywhere in a line allows the line, as it always did.
+	inlineAllowRegex = regexp.MustCompile(`gitleaks:allow(?:-next-line|-begin|-end)?`)
+
+	// inlineAllowOptionRegex matches an option following a gitleaks:allow
+	// comment, ex: rule=aws-access-token or reason="test fixture"
+	inlineAllowOptionRegex = regexp.MustCompile(`^\s+(rule|reason)=(?:"([^"]*)"|'([^']*)'|([^\s"']+))`)
+)
+
+// inlineAllow is a gitleaks:allow comment of a fragment. It allows the matches
+// on the lines StartLine to EndLine, counting from 0 like finding locations in
+// a fragment.
+type inlineAllow struct {
+	// directive is gitleaks:allow, gitleaks:allow-next-line or
+	// gitleaks:allow-begin
+	directive string
+
+	startLine int
+	endLine   int
+
+	// ruleIDs are the rules the comment applies to, every rule if empty
+	ruleIDs []string
+
+	reason string
+}
+
+// parseInlineAllows returns the gitleaks:allow comments of raw. A
+// gitleaks:allow-begin block without a gitleaks:allow-end extends to the end
+// of raw.
+func parseInlineAllows(raw string) []inlineAllow {
+	if !strings.Contains(raw, gitleaksAllowSignature) {
+		return nil
+	}
+
+	var (
+		allows []inlineAllow
+		open   []int
+	)
+	lines := strings.Split(raw, "\n")
+	for i, line := range lines {
+		for _, m := range inlineAllowRegex.FindAllStringIndex(line, -1) {
+			allow := inlineAllow{
+				directive: line[m[0]:m[1]],
+				startLine: i,
+				endLine:   i,
+			}
+			for rest := line[m[1]:]; ; {
+				option := inlineAllowOptionRegex.FindStringSubmatch(rest)
+				if option == nil {
+					break
+				}
+				value := option[2] + option[3] + option[4]
+				switch option[1] {
+				case "rule":
+					for _, ruleID := range strings.Split(value, ",") {
+						if ruleID = strings.TrimSpace(ruleID); ruleID != "" {
+							allow.ruleIDs = append(allow.ruleIDs, ruleID)
+						}
+					}
+				case "reason":
+					allow.reason = value
+				}
+				rest = rest[len(option[0]):]
+			}
+
+			switch allow.directive {
+			case allowNextLine:
+				allow.startLine, allow.endLine = i+1, i+1
+			case allowBlockBegin:
+				open = append(open, len(allows))
+			case allowBlockEnd:
+				if len(open) > 0 {
+					allows[open[len(open)-1]].endLine = i
+					open = open[:len(open)-1]
+				}
+				continue
+			}
+			allows = append(allows, allow)
+		}
+	}
+	for _, i := range open {
+		allows[i].endLine = len(lines) - 1
+	}
+	return allows
+}
+
+// inlineAllowed returns the gitleaks:allow comment of a fragment allowing a
+// match of a rule on the lines startLine to endLine, if any. A match is
+// allowed if one of its lines is.
+func (d *Detector) inlineAllowed(fragment Fragment, ruleID string, startLine, endLine int) (inlineAllow, bool) {
+	if d.IgnoreGitleaksAllow {
+		return inlineAllow{}, false
+	}
+	for _, allow := range fragment.inlineAllows {
+		if allow.endLine < startLine || allow.startLine > endLine {
+			continue
+		}
+		if d.RequireAllowReason && allow.reason == "" {
+			continue
+		}
+		if len(allow.ruleIDs) == 0 {
+			return allow, true
+		}
+		for _, id := range allow.ruleIDs {
+			if id == ruleID {
+				return allow, true
+			}
+		}
+	}
+	return inlineAllow{}, false
+}
+
+// warnMissingReasons logs the gitleaks:allow comments of a fragment ignored
+// because they have no reason.
+func (d *Detector) warnMissingReasons(fragment Fragment) {
+	if !d.RequireAllowReason || d.IgnoreGitleaksAllow {
+		return
+	}
+	for _, allow := range fragment.inlineAllows {
+		if allow.reason == "" {
+			log.Warn().Msgf("ignoring %s without a reason in %s", allow.directive, fragment.FilePath)
+		}
+	}
+}
+
+// String describes why a match is allowed by the comment.
+func (a inlineAllow) String() string {
+	directive := a.directive
+	if len(a.ruleIDs) > 0 {
+		directive += " rule=" + strings.Join(a.ruleIDs, ",")
+	}
+	var s string
+	switch a.directive {
+	case allowNextLine:
+		s = fmt.Sprintf("the previous line contains %s", directive)
+	case allowBlockBegin:
+		s = fmt.Sprintf("the match is in a %s block", directive)
+	default:
+		s = fmt.Sprintf("the line contains %s", directive)
+	}
+	if a.reason != "" {
+		s += fmt.Sprintf(", reason %q", a.reason)
+	}
+	return s
+}
@@ -0,0 +1,263 @@
+package detect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+// allowlist is an allowlist of a rule, or the global allowlist, which has
+// not expired.
+type allowlist struct {
+	*config.Allowlist
+
+	// scope is rule or global
+	scope string
+}
+
+// ruleAllowlists returns the allowlist and the named allowlists of rule which
+// have not expired.
+func (d *Detector) ruleAllowlists(rule config.Rule) []allowlist {
+	var allowlists []allowlist
+	if !rule.Allowlist.Expired(d.now) {
+		allowlists = append(allowlists, allowlist{&rule.Allowlist, "rule"})
+	}
+	for i := range rule.Allowlists {
+		if !rule.Allowlists[i].Expired(d.now) {
+			allowlists = append(allowlists, allowlist{&rule.Allowlists[i], "rule"})
+		}
+	}
+	return allowlists
+}
+
+// globalAllowlists returns the global allowlist and the global allowlists of
+// the extended configs which have not expired.
+func (d *Detector) globalAllowlists() []allowlist {
+	var allowlists []allowlist
+	if !d.Config.Allowlist.Expired(d.now) {
+		allowlists = append(allowlists, allowlist{&d.Config.Allowlist, "global"})
+	}
+	for i := range d.Config.Allowlists {
+		if !d.Config.Allowlists[i].Expired(d.now) {
+			allowlists = append(allowlists, allowlist{&d.Config.Allowlists[i], "global"})
+		}
+	}
+	return allowlists
+}
+
+// globalFragmentMatch returns the stage and reason of the first global
+// allowlist suppressing all the findings of the fragment, see fragmentMatch.
+func (d *Detector) globalFragmentMatch(fragment Fragment) (Stage, string) {
+	for _, a := range d.globalAllowlists() {
+		if _, stage, reason := a.fragmentMatch(fragment); stage != "" {
+			return stage, reason
+		}
+	}
+	return "", ""
+}
+
+// String names the allowlist in suppression reasons.
+func (a allowlist) String() string {
+	if a.Name != "" {
+		return fmt.Sprintf("%s allowlist %q", a.scope, a.Name)
+	}
+	return a.scope + " allowlist"
+}
+
+// stopWord names a stop word of the allowlist in suppression reasons.
+func (a allowlist) stopWord(word string) string {
+	if a.Name != "" {
+		return fmt.Sprintf("stop word %q of %s", word, a)
+	}
+	return fmt.Sprintf("%s stop word %q", a.scope, word)
+}
+
+// details returns the owner and reason of the allowlist to append to
+// suppression reasons.
+func (a allowlist) details() string {
+	var s string
+	if a.Owner != "" {
+		s += ", owned by " + a.Owner
+	}
+	if a.Reason != "" {
+		s += fmt.Sprintf(", reason %q", a.Reason)
+	}
+	return s
+}
+
+// fragmentMatch checks the commit and path criteria of the allowlist
+// against the fragment. applies is false if the allowlist cannot suppress any
+// finding of the fragment because one of the criteria of an AND allowlist does
+// not match. stage and reason are set if the allowlist suppresses all the
+// findings of the fragment.
+func (a allowlist) fragmentMatch(fragment Fragment) (applies bool, stage Stage, reason string) {
+	if !a.MatchesAll() {
+		switch {
+		case a.CommitAllowed(fragment.CommitSHA):
+			return true, StageCommitAllowlist, fmt.Sprintf("commit %s is in the %s%s", fragment.CommitSHA, a, a.details())
+		case a.PathAllowed(fragment.FilePath):
+			return true, StagePathAllowlist, fmt.Sprintf("path %s matches %s path %s%s",
+				fragment.FilePath, a, matchingRegex(a.Paths, fragment.FilePath), a.details())
+		}
+		return true, "", ""
+	}
+
+	criteria, ok := a.fragmentCriteria(fragment)
+	if !ok {
+		return false, "", ""
+	}
+	if len(criteria) == 0 || len(a.Regexes)+len(a.StopWords) > 0 {
+		// the findings decide
+		return true, "", ""
+	}
+	stage = StageCommitAllowlist
+	if len(a.Paths) > 0 {
+		stage = StagePathAllowlist
+	}
+	return true, stage, a.allMatch(criteria)
+}
+
+// applies returns false if the allowlist cannot suppress any finding of the
+// fragment, see fragmentMatch.
+func (a allowlist) applies(fragment Fragment) bool {
+	applies, _, _ := a.fragmentMatch(fragment)
+	return applies
+}
+
+// commitAllowed returns true if a global allowlist suppresses all the
+// findings of the commit sha, whatever their path.
+func (d *Detector) commitAllowed(sha string) bool {
+	stage, _ := d.globalFragmentMatch(Fragment{CommitSHA: sha})
+	return stage == StageCommitAllowlist
+}
+
+// fragmentCriteria describes the commit and path criteria of an AND
+// allowlist, ok is false if one of them does not match the fragment.
+func (a allowlist) fragmentCriteria(fragment Fragment) (criteria []string, ok bool) {
+	if len(a.Commits) > 0 {
+		if !a.CommitAllowed(fragment.CommitSHA) {
+			return nil, false
+		}
+		criteria = append(criteria, "commit "+fragment.CommitSHA)
+	}
+	if len(a.Paths) > 0 {
+		if !a.PathAllowed(fragment.FilePath) {
+			return nil, false
+		}
+		criteria = append(criteria, fmt.Sprintf("path %s matches %s", fragment.FilePath, matchingRegex(a.Paths, fragment.FilePath)))
+	}
+	return criteria, true
+}
+
+// allMatch returns the suppression reason of an AND allowlist whose criteria
+// all match.
+func (a allowlist) allMatch(criteria []string) string {
+	return fmt.Sprintf("all the criteria of the %s match: %s%s", a, strings.Join(criteria, ", "), a.details())
+}
+
+// regexTarget returns the part of the finding the regexes of the allowlist
+// are tested against.
+func (a allowlist) regexTarget(finding *report.Finding) string {
+	switch a.RegexTarget {
+	case "match":
+		return finding.Match
+	case "line":
+		return finding.Line
+	}
+	return finding.Secret
+}
+
+// regexAllowed returns true if a regex of one of the OR allowlists matches
+// the target of the allowlist and the finding must be skipped, see suppress.
+func (d *Detector) regexAllowed(rule config.Rule, finding *report.Finding, allowlists []allowlist) bool {
+	for _, a := range allowlists {
+		if a.MatchesAll() {
+			continue
+		}
+		target := a.regexTarget(finding)
+		if a.RegexAllowed(target) &&
+			d.suppress(rule, StageRegexAllowlist, finding, "%s regex %s matches the %s%s",
+				a, matchingRegex(a.Regexes, target), regexTargetName(a.RegexTarget), a.details()) {
+			return true
+		}
+	}
+	return false
+}
+
+// stopWordAllowed returns true if the secret of the finding contains a stop
+// word of one of the OR allowlists and the finding must be skipped, see
+// suppress.
+func (d *Detector) stopWordAllowed(rule config.Rule, finding *report.Finding, allowlists []allowlist) bool {
+	for _, a := range allowlists {
+		if a.MatchesAll() {
+			continue
+		}
+		if a.ContainsStopWord(finding.Secret) &&
+			d.suppress(rule, StageStopWords, finding, "the secret contains %s%s",
+				a.stopWord(matchingStopWord(a.StopWords, finding.Secret)), a.details()) {
+			return true
+		}
+	}
+	return false
+}
+
+// allCriteriaAllowed returns true if all the criteria of one of the AND
+// allowlists with regexes or stop words match the finding and it must be
+// skipped, see suppress.
+func (d *Detector) allCriteriaAllowed(rule config.Rule, fragment Fragment, finding *report.Finding, allowlists []allowlist) bool {
+	for _, a := range allowlists {
+		if !a.MatchesAll() {
+			continue
+		}
+		criteria, stage, ok := a.findingCriteria(fragment, finding.Secret, a.regexTarget(finding))
+		if ok && d.suppress(rule, stage, finding, "%s", a.allMatch(criteria)) {
+			return true
+		}
+	}
+	return false
+}
+
+// findingCriteria describes the criteria of an AND allowlist, ok is false if
+// it has no regexes or stop words or if one of the criteria does not match
+// the secret, the regex target or the fragment. stage is the stage of the
+// last criterion checked.
+func (a allowlist) findingCriteria(fragment Fragment, secret, target string) (criteria []string, stage Stage, ok bool) {
+	if len(a.Regexes)+len(a.StopWords) == 0 {
+		return nil, "", false
+	}
+	if criteria, ok = a.fragmentCriteria(fragment); !ok {
+		return nil, "", false
+	}
+	if len(a.Regexes) > 0 {
+		if !a.RegexAllowed(target) {
+			return nil, "", false
+		}
+		criteria = append(criteria, fmt.Sprintf("regex %s matches the %s", matchingRegex(a.Regexes, target), regexTargetName(a.RegexTarget)))
+		stage = StageRegexAllowlist
+	}
+	if len(a.StopWords) > 0 {
+		if !a.ContainsStopWord(secret) {
+			return nil, "", false
+		}
+		criteria = append(criteria, fmt.Sprintf("the secret contains stop word %q", matchingStopWord(a.StopWords, secret)))
+		stage = StageStopWords
+	}
+	return criteria, stage, true
+}
+
+// secretAllowed returns true if one of allowlists allows secret, found in
+// fragment.
+func secretAllowed(fragment Fragment, allowlists []allowlist, secret string) bool {
+	for _, a := range allowlists {
+		if a.MatchesAll() {
+			if _, _, ok := a.findingCriteria(fragment, secret, secret); ok {
+				return true
+			}
+		} else if a.RegexAllowed(secret) || a.ContainsStopWord(secret) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package detect
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/h2non/filetype"
+	"github.com/rs/zerolog/log"
+
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+const (
+	// archiveSeparator separates the path of an archive from the path
+	// of a member inside of it, ex: outer.jar!/BOOT-INF/classes/application.properties
+	archiveSeparator = "!/"
+
+	// headerSize is the number of bytes needed to identify all supported
+	// archive formats. Tar has the largest offset (ustar magic at 257).
+	headerSize = 262
+
+	// defaultMaxInMemoryArchiveMegaBytes bounds the size of the zip archives
+	// read into memory, see detectZip, unless MaxTargetMegaBytes is set.
+	defaultMaxInMemoryArchiveMegaBytes = 256
+)
+
+// archiveFormat returns the archive format of the content starting with head
+// or an empty string if the content is not a supported archive. Jar, war and
+// whl files are zip archives and tgz files are gzipped tar archives.
+func archiveFormat(head []byte) string {
+	for _, format := range []string{"zip", "tar", "gz"} {
+		if filetype.Is(head, format) {
+			return format
+		}
+	}
+	return ""
+}
+
+// archiveMemberPath returns the path reported for a member of the archive
+// located at archivePath.
+func archiveMemberPath(archivePath string, name string) string {
+	if archivePath == "" {
+		return name
+	}
+	return archivePath + archiveSeparator + name
+}
+
+// detectContent scans the content of r. If the content is an archive and
+// the archive depth allows it, the archive is extracted and each of its
+// members is scanned instead, until ctx is done. depth is the number of
+// archives r is nested in.
+func (d *Detector) detectContent(ctx context.Context, r io.Reader, path string, symlink string, depth int, add func(report.Finding)) error {
+	var head []byte
+	if f, ok := r.(*os.File); ok {
+		head = make([]byte, headerSize)
+		n, err := f.ReadAt(head, 0)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		head = head[:n]
+	} else {
+		br := bufio.NewReader(r)
+		head, _ = br.Peek(headerSize)
+		r = br
+	}
+
+	if depth < d.MaxArchiveDepth {
+		if format := archiveFormat(head); format != "" {
+			d.detectArchive(ctx, r, format, path, depth+1, add)
+			return nil
+		}
+	}
+	return d.detectChunks(r, path, symlink, true, add)
+}
+
+// detectArchive extracts the members of an archive and scans each of them.
+// depth is the nesting level of the archive, starting at 1. An archive which
+// cannot be extracted is skipped, or the rest of it once some members were
+// scanned. The extraction stops between two members once ctx is done.
+func (d *Detector) detectArchive(ctx context.Context, r io.Reader, format string, path string, depth int, add func(report.Finding)) {
+	log.Debug().Msgf("scanning archive: %s", path)
+	if err := d.extractArchive(ctx, r, format, path, depth, add); err != nil && ctx.Err() == nil {
+		log.Warn().Err(err).Msgf("could not extract archive %s, skipping the rest of it", archiveName(path))
+	}
+}
+
+// archiveName names the archive at path in logs, path is empty for stdin.
+func archiveName(path string) string {
+	if path == "" {
+		return "from stdin"
+	}
+	return path
+}
+
+func (d *Detector) extractArchive(ctx context.Context, r io.Reader, format string, path string, depth int, add func(report.Finding)) error {
+	switch format {
+	case "zip":
+		return d.detectZip(ctx, r, path, depth, add)
+	case "tar":
+		return d.detectTar(ctx, r, path, depth, add)
+	case "gz":
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+
+		// a gzipped tar (.tar.gz, .tgz) counts as a single archive
+		br := bufio.NewReader(gz)
+		head, _ := br.Peek(headerSize)
+		if archiveFormat(head) == "tar" {
+			return d.detectTar(ctx, br, path, depth, add)
+		}
+		name := gz.Name
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(path), ".gz")
+		}
+		return d.detectContent(ctx, br, archiveMemberPath(path, name), "", depth, add)
+	}
+	return nil
+}
+
+func (d *Detector) detectZip(ctx context.Context, r io.Reader, path string, depth int, add func(report.Finding)) error {
+	var (
+		readerAt io.ReaderAt
+		size     int64
+	)
+	if f, ok := r.(*os.File); ok {
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		readerAt, size = f, info.Size()
+	} else {
+		// zip archives are indexed from the end, so nested archives
+		// and archives from stdin have to be read into memory
+		maxMegaBytes := d.MaxTargetMegaBytes
+		if maxMegaBytes <= 0 {
+			maxMegaBytes = defaultMaxInMemoryArchiveMegaBytes
+		}
+		limit := int64(maxMegaBytes) * 1000000
+		b, err := io.ReadAll(io.LimitReader(r, limit+1))
+		if err != nil {
+			return err
+		}
+		if int64(len(b)) > limit {
+			log.Warn().Msgf("skipping archive %s larger than %d MB", archiveName(path), maxMegaBytes)
+			return nil
+		}
+		readerAt, size = bytes.NewReader(b), int64(len(b))
+	}
+
+	zr, err := zip.NewReader(readerAt, size)
+	if err != nil {
+		log.Debug().Err(err).Msgf("could not read zip archive %s, scanning it as plain content", archiveName(path))
+		return d.detectChunks(io.NewSectionReader(readerAt, 0, size), path, "", false, add)
+	}
+	for _, zf := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if zf.FileInfo().IsDir() || zf.UncompressedSize64 == 0 {
+			continue
+		}
+		if d.MaxTargetMegaBytes > 0 {
+			rawLength := zf.UncompressedSize64 / 1000000
+			if rawLength > uint64(d.MaxTargetMegaBytes) {
+				log.Debug().Msgf("skipping archive member: %s scan due to size: %d",
+					archiveMemberPath(path, zf.Name), rawLength)
+				continue
+			}
+		}
+		memberPath := archiveMemberPath(path, zf.Name)
+		rc, err := zf.Open()
+		if err != nil {
+			log.Warn().Err(err).Msgf("skipping archive member %s", memberPath)
+			continue
+		}
+		err = d.detectContent(ctx, rc, memberPath, "", depth, add)
+		rc.Close()
+		if err != nil {
+			log.Warn().Err(err).Msgf("skipping archive member %s", memberPath)
+		}
+	}
+	return nil
+}
+
+func (d *Detector) detectTar(ctx context.Context, r io.Reader, path string, depth int, add func(report.Finding)) error {
+	tr := tar.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg || header.Size == 0 {
+			continue
+		}
+		if d.MaxTargetMegaBytes > 0 {
+			rawLength := header.Size / 1000000
+			if rawLength > int64(d.MaxTargetMegaBytes) {
+				log.Debug().Msgf("skipping archive member: %s scan due to size: %d",
+					archiveMemberPath(path, header.Name), rawLength)
+				continue
+			}
+		}
+		memberPath := archiveMemberPath(path, header.Name)
+		if err := d.detectContent(ctx, tr, memberPath, "", depth, add); err != nil {
+			log.Warn().Err(err).Msgf("skipping archive member %s", memberPath)
+		}
+	}
+}
@@ -45,7 +45,14 @@ func LoadBaseline(baselinePath string) ([]report.Finding, error) {
 	var previousFindings []report.Finding
 	err = json.Unmarshal(bytes, &previousFindings)
 	if err != nil {
-		return nil, fmt.Errorf("the format of the file %s is not supported", baselinePath)
+		// the JSON report of a partial scan wraps its findings
+		var partialReport struct {
+			Findings []report.Finding `json:"findings"`
+		}
+		if json.Unmarshal(bytes, &partialReport) != nil || partialReport.Findings == nil {
+			return nil, fmt.Errorf("the format of the file %s is not supported", baselinePath)
+		}
+		previousFindings = partialReport.Findings
 	}
 
 	return previousFindings, nil
@@ -1,6 +1,7 @@
 package detect
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -75,6 +76,10 @@ func TestFileLoadBaseline(t *testing.T) {
 			Filename:      "../testdata/baseline/baseline.sarif",
 			ExpectedError: errors.New("the format of the file ../testdata/baseline/baseline.sarif is not supported"),
 		},
+		{
+			Filename:      "../testdata/baseline/partial.json",
+			ExpectedError: nil,
+		},
 		{
 			Filename:      "../testdata/baseline/notfound.json",
 			ExpectedError: errors.New("could not open ../testdata/baseline/notfound.json"),
@@ -131,7 +136,7 @@ func TestIgnoreIssuesInBaseline(t *testing.T) {
 		d, _ := NewDetectorDefaultConfig()
 		d.baseline = test.baseline
 		for _, finding := range test.findings {
-			d.addFinding(finding)
+			d.addFinding(context.Background(), finding)
 		}
 		assert.Len(t, d.findings, test.expectCount)
 	}
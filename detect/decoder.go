@@ -0,0 +1,277 @@
+package detect
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// base64 and hex strings must be reasonably long, short runs of
+	// alphanumeric characters are usually just words
+	base64Pat  = regexp.MustCompile(`[A-Za-z0-9+/_\-]{16,}={0,2}`)
+	hexPat     = regexp.MustCompile(`\b(?:[0-9A-Fa-f]{2}){8,}\b`)
+	percentPat = regexp.MustCompile(`(?:%[0-9A-Fa-f]{2})+`)
+	unicodePat = regexp.MustCompile(`(?:\\u[0-9A-Fa-f]{4})+`)
+
+	base64Encodings = []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	}
+)
+
+// encoder finds and decodes substrings encoded in a single encoding
+type encoder struct {
+	name    string
+	pattern *regexp.Regexp
+	decode  func(string) (string, bool)
+}
+
+var encoders = []encoder{
+	{name: "url", pattern: percentPat, decode: decodePercent},
+	{name: "unicode", pattern: unicodePat, decode: decodeUnicode},
+	{name: "hex", pattern: hexPat, decode: decodeHex},
+	{name: "base64", pattern: base64Pat, decode: decodeBase64},
+}
+
+// encodedSegment is a decoded substring of a fragment
+type encodedSegment struct {
+	// start and end are the indices of the decoded value in the decoded text
+	start int
+	end   int
+
+	// origStart and origEnd are the indices of the encoded value in the
+	// original fragment
+	origStart int
+	origEnd   int
+
+	// depth is the decode pass that produced this segment
+	depth int
+
+	// chain contains the encodings of the value, outermost first
+	chain []string
+}
+
+type encodedCandidate struct {
+	start    int
+	end      int
+	encoding string
+	decoded  string
+}
+
+// decode replaces every encoded substring of raw with its decoded value.
+// segments are the segments produced by previous passes, raw being the
+// text they produced. The returned segments map the returned text back to
+// the original fragment. No segments are returned if nothing was decoded.
+func decode(raw string, segments []encodedSegment, depth int) (string, []encodedSegment) {
+	candidates := findEncoded(raw)
+	if len(candidates) == 0 {
+		return raw, nil
+	}
+
+	var (
+		sb          strings.Builder
+		newSegments []encodedSegment
+		last        int
+		delta       int
+		i           int
+	)
+	shifted := func(s encodedSegment) encodedSegment {
+		s.start += delta
+		s.end += delta
+		return s
+	}
+	for j := 0; j < len(candidates); {
+		for i < len(segments) && segments[i].end <= candidates[j].start {
+			newSegments = append(newSegments, shifted(segments[i]))
+			i++
+		}
+
+		// a value decoded from a previously decoded segment inherits the
+		// encodings of that segment. The new segment covers the whole of
+		// the old segments it overlaps, along with every other candidate
+		// found in them.
+		var chain []string
+		groupStart, groupEnd := candidates[j].start, candidates[j].end
+		group := j
+		for {
+			if i < len(segments) && segments[i].start < groupEnd {
+				if chain == nil {
+					chain = append(chain, segments[i].chain...)
+				}
+				if segments[i].start < groupStart {
+					groupStart = segments[i].start
+				}
+				if segments[i].end > groupEnd {
+					groupEnd = segments[i].end
+				}
+				i++
+				continue
+			}
+			if group+1 < len(candidates) && candidates[group+1].start < groupEnd {
+				group++
+				if candidates[group].end > groupEnd {
+					groupEnd = candidates[group].end
+				}
+				continue
+			}
+			break
+		}
+		chain = append(chain, candidates[j].encoding)
+
+		sb.WriteString(raw[last:groupStart])
+		start := sb.Len()
+		pos := groupStart
+		for ; j <= group; j++ {
+			sb.WriteString(raw[pos:candidates[j].start])
+			sb.WriteString(candidates[j].decoded)
+			pos = candidates[j].end
+		}
+		sb.WriteString(raw[pos:groupEnd])
+		newSegments = append(newSegments, encodedSegment{
+			start:     start,
+			end:       sb.Len(),
+			origStart: originalIndex(groupStart, segments, false),
+			origEnd:   originalIndex(groupEnd, segments, true),
+			depth:     depth,
+			chain:     chain,
+		})
+		delta += (sb.Len() - start) - (groupEnd - groupStart)
+		last = groupEnd
+	}
+	for ; i < len(segments); i++ {
+		newSegments = append(newSegments, shifted(segments[i]))
+	}
+	sb.WriteString(raw[last:])
+
+	return sb.String(), newSegments
+}
+
+// findEncoded returns the non-overlapping encoded substrings of raw that
+// decode to printable text, ordered by position.
+func findEncoded(raw string) []encodedCandidate {
+	var candidates []encodedCandidate
+	for _, e := range encoders {
+		for _, m := range e.pattern.FindAllStringIndex(raw, -1) {
+			if decoded, ok := e.decode(raw[m[0]:m[1]]); ok {
+				candidates = append(candidates, encodedCandidate{
+					start:    m[0],
+					end:      m[1],
+					encoding: e.name,
+					decoded:  decoded,
+				})
+			}
+		}
+	}
+
+	// encoders are ordered by precedence, the sort is stable so the
+	// first encoder wins when two candidates start at the same index
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].start < candidates[j].start
+	})
+	var result []encodedCandidate
+	for _, c := range candidates {
+		if len(result) > 0 && c.start < result[len(result)-1].end {
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// originalIndex maps an index of the decoded text to an index of the original
+// fragment. Indices inside of a segment map to the start of the encoded value,
+// or to its end if isEnd is set.
+func originalIndex(i int, segments []encodedSegment, isEnd bool) int {
+	shift := 0
+	for _, s := range segments {
+		if i <= s.start {
+			break
+		}
+		if i < s.end {
+			if isEnd {
+				return s.origEnd
+			}
+			return s.origStart
+		}
+		shift += (s.origEnd - s.origStart) - (s.end - s.start)
+	}
+	return i + shift
+}
+
+// originalSpan maps the indices of a match in the decoded text to the
+// indices in the original fragment.
+func originalSpan(matchIndex []int, segments []encodedSegment) []int {
+	return []int{
+		originalIndex(matchIndex[0], segments, false),
+		originalIndex(matchIndex[1], segments, true),
+	}
+}
+
+// decodedSegment returns the first segment produced by the given decode pass
+// that overlaps the match, or nil if the match only covers text which has
+// already been scanned in a previous pass.
+func decodedSegment(matchIndex []int, segments []encodedSegment, depth int) *encodedSegment {
+	for i, s := range segments {
+		if s.depth == depth && matchIndex[0] < s.end && s.start < matchIndex[1] {
+			return &segments[i]
+		}
+	}
+	return nil
+}
+
+func decodePercent(s string) (string, bool) {
+	decoded, err := url.PathUnescape(s)
+	if err != nil {
+		return "", false
+	}
+	return decoded, isPrintable(decoded)
+}
+
+func decodeUnicode(s string) (string, bool) {
+	decoded, err := strconv.Unquote(`"` + s + `"`)
+	if err != nil {
+		return "", false
+	}
+	return decoded, isPrintable(decoded)
+}
+
+func decodeHex(s string) (string, bool) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return "", false
+	}
+	return string(b), isPrintable(string(b))
+}
+
+func decodeBase64(s string) (string, bool) {
+	for _, enc := range base64Encodings {
+		b, err := enc.DecodeString(s)
+		if err == nil {
+			return string(b), isPrintable(string(b))
+		}
+	}
+	return "", false
+}
+
+// isPrintable reports whether s is non-empty utf-8 text without control
+// characters other than whitespace.
+func isPrintable(s string) bool {
+	if s == "" || !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
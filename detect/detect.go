@@ -1,16 +1,16 @@
 package detect
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/zricethezav/gitleaks/v8/config"
 	"github.com/zricethezav/gitleaks/v8/report"
+	"github.com/zricethezav/gitleaks/v8/verify"
 
 	ahocorasick "github.com/BobuSumisu/aho-corasick"
 	"github.com/fatih/semgroup"
@@ -22,6 +22,10 @@ import (
 const (
 	gitleaksAllowSignature = "gitleaks:allow"
 	chunkSize              = 10 * 1_000 // 10kb
+
+	// DefaultThreads is the number of files, or files changed by commits,
+	// scanned at the same time unless SetThreads is called.
+	DefaultThreads = 40
 )
 
 // Detector is the main detector struct
@@ -40,6 +44,19 @@ type Detector struct {
 	// files larger than this will be skipped
 	MaxTargetMegaBytes int
 
+	// MaxDecodeDepth is the maximum number of times encoded (base64, hex,
+	// percent or unicode escaped) content is decoded and scanned again.
+	// Content is not decoded if this is 0.
+	MaxDecodeDepth int
+
+	// MaxArchiveDepth is the maximum depth of nested archives that will be
+	// extracted and scanned. Archives are not extracted if this is 0.
+	MaxArchiveDepth int
+
+	// Verifier checks whether the secrets of findings are active. Findings
+	// are not verified if it is nil.
+	Verifier *verify.Verifier
+
 	// followSymlinks is a flag to enable scanning symlink files
 	FollowSymlinks bool
 
@@ -49,12 +66,42 @@ type Detector struct {
 	// IgnoreGitleaksAllow is a flag to ignore gitleaks:allow comments.
 	IgnoreGitleaksAllow bool
 
+	// RequireAllowReason ignores the gitleaks:allow comments without a
+	// reason="..." option.
+	RequireAllowReason bool
+
+	// IncludeSuppressed keeps the findings dropped by allowlists, stop words,
+	// the entropy threshold, gitleaks:allow comments, .gitleaksignore files or
+	// the baseline, with Suppressed set and the reason in SuppressionReason.
+	IncludeSuppressed bool
+
+	// OnFinding, if not nil, receives the findings of DetectGit,
+	// DetectFiles, DetectImage and DetectReader as soon as they are found,
+	// one call at a time. The findings are then not kept and these methods
+	// return none.
+	OnFinding func(report.Finding)
+
+	// OnProgress, if not nil, is called after every file, or file changed by
+	// a commit, is scanned by DetectGit, DetectFiles and DetectImage, one call
+	// at a time.
+	OnProgress func(Progress)
+
+	// progress is the progress of the scan, it is guarded by findingMutex.
+	progress Progress
+
 	// commitMap is used to keep track of commits that have been scanned.
 	// This is only used for logging purposes and git scans.
 	commitMap map[string]bool
 
+	// commitOrder lists the commits of a git scan in the order git output
+	// them and pendingFiles counts the files of each commit which are not
+	// scanned yet. They set progress.LastCommit, see advanceCommits.
+	commitOrder    []string
+	pendingFiles   map[string]int
+	scannedCommits int
+
 	// findingMutex is to prevent concurrent access to the
-	// findings slice when adding findings.
+	// findings slice, commitMap and progress when adding findings.
 	findingMutex *sync.Mutex
 
 	// findings is a slice of report.Findings. This is the result
@@ -72,11 +119,25 @@ type Detector struct {
 	// path to baseline
 	baselinePath string
 
-	// gitleaksIgnore
-	gitleaksIgnore map[string]bool
+	// gitleaksIgnore holds the entries of the .gitleaksignore files
+	gitleaksIgnore *gitleaksIgnore
 
 	// Sema (https://github.com/fatih/semgroup) controls the concurrency
 	Sema *semgroup.Group
+
+	// nestedConfigs are the configs of the .gitleaks.toml files found in
+	// the subdirectories of nestedRoot, by slash separated directory
+	// relative to nestedRoot, see AddNestedConfigs.
+	nestedConfigs map[string]*nestedConfig
+	nestedRoot    string
+
+	// now is when the detector was created, the allowlists which expired
+	// before are not applied.
+	now time.Time
+
+	// explain receives the decisions made about every rule while a fragment
+	// is explained, see Explain.
+	explain func(Decision)
 }
 
 // Fragment contains the data to be scanned
@@ -98,21 +159,37 @@ type Fragment struct {
 	// keywords is a map of all the keywords contain within the contents
 	// of this fragment
 	keywords map[string]bool
+
+	// suppression is the reason all the findings of this fragment are
+	// suppressed, if any
+	suppression string
+
+	// inlineAllows are the gitleaks:allow comments of the fragment
+	inlineAllows []inlineAllow
 }
 
 // NewDetector creates a new detector with the given config
 func NewDetector(cfg config.Config) *Detector {
 	return &Detector{
 		commitMap:      make(map[string]bool),
-		gitleaksIgnore: make(map[string]bool),
+		pendingFiles:   make(map[string]int),
+		gitleaksIgnore: newGitleaksIgnore(),
 		findingMutex:   &sync.Mutex{},
 		findings:       make([]report.Finding, 0),
 		Config:         cfg,
 		prefilter:      *ahocorasick.NewTrieBuilder().AddStrings(cfg.Keywords).Build(),
-		Sema:           semgroup.NewGroup(context.Background(), 40),
+		Sema:           semgroup.NewGroup(context.Background(), DefaultThreads+1),
+		now:            time.Now(),
 	}
 }
 
+// SetThreads sets the number of files, or files changed by commits, scanned
+// at the same time. Sema has one more worker for the walk of
+// sources.DirectoryTargets, which shares it.
+func (d *Detector) SetThreads(threads int) {
+	d.Sema = semgroup.NewGroup(context.Background(), int64(threads)+1)
+}
+
 // NewDetectorDefaultConfig creates a new detector with the default config
 func NewDetectorDefaultConfig() (*Detector, error) {
 	viper.SetConfigType("toml")
@@ -132,28 +209,6 @@ func NewDetectorDefaultConfig() (*Detector, error) {
 	return NewDetector(cfg), nil
 }
 
-func (d *Detector) AddGitleaksIgnore(gitleaksIgnorePath string) error {
-	log.Debug().Msgf("found .gitleaksignore file: %s", gitleaksIgnorePath)
-	file, err := os.Open(gitleaksIgnorePath)
-
-	if err != nil {
-		return err
-	}
-
-	// https://github.com/securego/gosec/issues/512
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Warn().Msgf("Error closing .gitleaksignore file: %s\n", err)
-		}
-	}()
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		d.gitleaksIgnore[scanner.Text()] = true
-	}
-	return nil
-}
-
 // DetectBytes scans the given bytes and returns a list of findings
 func (d *Detector) DetectBytes(content []byte) []report.Finding {
 	return d.DetectString(string(content))
@@ -168,22 +223,106 @@ func (d *Detector) DetectString(content string) []report.Finding {
 
 // Detect scans the given fragment and returns a list of findings
 func (d *Detector) Detect(fragment Fragment) []report.Finding {
-	var findings []report.Finding
+	return d.DetectContext(context.Background(), fragment)
+}
 
-	// initiate fragment keywords
-	fragment.keywords = make(map[string]bool)
+// DetectContext is Detect verifying the secrets of the findings, if a
+// Verifier is set, until ctx is done.
+func (d *Detector) DetectContext(ctx context.Context, fragment Fragment) []report.Finding {
+	findings := d.detect(fragment)
+	for i := range findings {
+		d.verifyAndRedact(ctx, &findings[i])
+	}
+	return findings
+}
+
+// detect scans the given fragment and returns its findings, which are
+// neither verified nor redacted yet, see addFinding.
+func (d *Detector) detect(fragment Fragment) []report.Finding {
+	if nc := d.nestedConfigFor(fragment.FilePath); nc != nil {
+		log.Debug().Msgf("scanning %s with %s", fragment.FilePath, nc.path)
+		return nc.detector(d).detect(fragment)
+	}
+
+	var findings []report.Finding
 
 	// check if filepath is allowed
-	if fragment.FilePath != "" && (d.Config.Allowlist.PathAllowed(fragment.FilePath) ||
-		fragment.FilePath == d.Config.Path || (d.baselinePath != "" && fragment.FilePath == d.baselinePath)) {
+	if fragment.FilePath != "" && (fragment.FilePath == d.Config.Path ||
+		(d.baselinePath != "" && fragment.FilePath == d.baselinePath)) {
 		return findings
 	}
+	if stage, reason := d.globalFragmentMatch(fragment); fragment.FilePath != "" && stage == StagePathAllowlist {
+		if !d.IncludeSuppressed {
+			return findings
+		}
+		fragment.suppression = suppressionReason(stage, reason)
+	}
+
+	return d.fragmentFindings(d.detectFragment(fragment))
+}
+
+// fragmentFindings drops the findings of a fragment which are reported as an
+// auxiliary match of another finding, ignored by their rule or secret, or
+// superseded by the finding of a specific rule.
+func (d *Detector) fragmentFindings(findings []report.Finding) []report.Finding {
+	return d.filter(d.ignoreSecrets(d.removeAuxiliary(findings)))
+}
+
+// verifyAndRedact checks whether the secret of a finding is active, if a
+// Verifier is set, and then redacts it.
+func (d *Detector) verifyAndRedact(ctx context.Context, finding *report.Finding) {
+	if d.Verifier != nil && !finding.Suppressed {
+		d.Verifier.Verify(ctx, d.ruleOf(*finding), finding)
+	}
+	if d.Redact > 0 {
+		finding.Redact(d.Redact)
+	}
+}
+
+// ruleOf returns the rule which reported a finding, from the config applying
+// to its file.
+func (d *Detector) ruleOf(finding report.Finding) config.Rule {
+	if nc := d.nestedConfigFor(finding.File); nc != nil {
+		return nc.config.Rules[finding.RuleID]
+	}
+	return d.Config.Rules[finding.RuleID]
+}
+
+// detectFragment scans a fragment as is and then once more for every decode
+// pass that was able to decode something.
+func (d *Detector) detectFragment(fragment Fragment) []report.Finding {
+	var findings []report.Finding
+
+	// initiate fragment keywords
+	fragment.keywords = make(map[string]bool)
 
 	// add newline indices for location calculation in detectRule
 	fragment.newlineIndices = regexp.MustCompile("\n").FindAllStringIndex(fragment.Raw, -1)
 
+	fragment.inlineAllows = parseInlineAllows(fragment.Raw)
+	d.warnMissingReasons(fragment)
+
+	currentRaw := fragment.Raw
+	var segments []encodedSegment
+	for depth := 0; ; depth++ {
+		findings = append(findings, d.detectDecoded(fragment, currentRaw, segments, depth)...)
+		if depth >= d.MaxDecodeDepth {
+			break
+		}
+		if currentRaw, segments = decode(currentRaw, segments, depth+1); segments == nil {
+			break
+		}
+	}
+	return findings
+}
+
+// detectDecoded scans the raw content of a fragment, decoded depth times, with
+// every rule whose keywords are found in it.
+func (d *Detector) detectDecoded(fragment Fragment, raw string, segments []encodedSegment, depth int) []report.Finding {
+	var findings []report.Finding
+
 	// build keyword map for prefiltering rules
-	normalizedRaw := strings.ToLower(fragment.Raw)
+	normalizedRaw := strings.ToLower(raw)
 	matches := d.prefilter.MatchString(normalizedRaw)
 	for _, m := range matches {
 		fragment.keywords[normalizedRaw[m.Pos():int(m.Pos())+len(m.Match())]] = true
@@ -193,7 +332,7 @@ func (d *Detector) Detect(fragment Fragment) []report.Finding {
 		if len(rule.Keywords) == 0 {
 			// if not keywords are associated with the rule always scan the
 			// fragment using the rule
-			findings = append(findings, d.detectRule(fragment, rule)...)
+			findings = append(findings, d.detectRule(fragment, raw, segments, depth, rule)...)
 			continue
 		}
 		fragmentContainsKeyword := false
@@ -204,19 +343,42 @@ func (d *Detector) Detect(fragment Fragment) []report.Finding {
 			}
 		}
 		if fragmentContainsKeyword {
-			findings = append(findings, d.detectRule(fragment, rule)...)
+			findings = append(findings, d.detectRule(fragment, raw, segments, depth, rule)...)
+		} else {
+			d.trace(rule, StageKeywords, nil, "none of the keywords %s is in the content", strings.Join(rule.Keywords, ", "))
 		}
 	}
-	return filter(findings, d.Redact)
+	return findings
 }
 
-// detectRule scans the given fragment for the given rule and returns a list of findings
-func (d *Detector) detectRule(fragment Fragment, rule config.Rule) []report.Finding {
+// detectRule scans the given fragment for the given rule and returns a list of findings.
+// raw is the content of the fragment after depth decode passes, segments map the
+// decoded content back to the fragment.
+func (d *Detector) detectRule(fragment Fragment, raw string, segments []encodedSegment, depth int, rule config.Rule) []report.Finding {
 	var findings []report.Finding
 
 	// check if filepath or commit is allowed for this rule
-	if rule.Allowlist.CommitAllowed(fragment.CommitSHA) ||
-		rule.Allowlist.PathAllowed(fragment.FilePath) {
+	suppression := fragment.suppression
+	var allowlists []allowlist
+	for _, a := range d.ruleAllowlists(rule) {
+		applies, stage, reason := a.fragmentMatch(fragment)
+		if stage != "" && d.suppressRule(rule, stage, &suppression, "%s", reason) {
+			return findings
+		}
+		if applies {
+			allowlists = append(allowlists, a)
+		}
+	}
+	// the global allowlists suppressing the whole fragment are checked by
+	// Detect
+	for _, global := range d.globalAllowlists() {
+		if global.applies(fragment) {
+			allowlists = append(allowlists, global)
+		}
+	}
+
+	// path only rules have already been checked before decoding
+	if depth > 0 && rule.Regex == nil {
 		return findings
 	}
 
@@ -230,14 +392,19 @@ func (d *Detector) detectRule(fragment Fragment, rule config.Rule) []report.Find
 				RuleID:      rule.RuleID,
 				Match:       fmt.Sprintf("file detected: %s", fragment.FilePath),
 				Tags:        rule.Tags,
+				Severity:    rule.Severity,
+				Confidence:  rule.Confidence,
 			}
+			markSuppressed(&finding, suppression)
 			return append(findings, finding)
 		}
+		d.trace(rule, StagePath, nil, "path %s does not match %s", fragment.FilePath, rule.Path)
 	} else if rule.Path != nil {
 		// if path is set _and_ a regex is set, then we need to check both
 		// so if the path does not match, then we should return early and not
 		// consider the regex
 		if !rule.Path.MatchString(fragment.FilePath) {
+			d.trace(rule, StagePath, nil, "path %s does not match %s", fragment.FilePath, rule.Path)
 			return findings
 		}
 	}
@@ -252,23 +419,38 @@ func (d *Detector) detectRule(fragment Fragment, rule config.Rule) []report.Find
 		rawLength := len(fragment.Raw) / 1000000
 		if rawLength > d.MaxTargetMegaBytes {
 			log.Debug().Msgf("skipping file: %s scan due to size: %d", fragment.FilePath, rawLength)
+			d.trace(rule, StageSize, nil, "content is larger than %d MB", d.MaxTargetMegaBytes)
 			return findings
 		}
 	}
 
-	matchIndices := rule.Regex.FindAllStringIndex(fragment.Raw, -1)
+	matchIndices := rule.Regex.FindAllStringIndex(raw, -1)
 	for _, matchIndex := range matchIndices {
 		// extract secret from match
-		secret := strings.Trim(fragment.Raw[matchIndex[0]:matchIndex[1]], "\n")
+		secret := strings.Trim(raw[matchIndex[0]:matchIndex[1]], "\n")
+
+		// matches in decoded content are located at the encoded
+		// value in the original fragment
+		var decodeChain string
+		originalIndex := matchIndex
+		if depth > 0 {
+			segment := decodedSegment(matchIndex, segments, depth)
+			if segment == nil {
+				// this match has been found in a previous pass
+				continue
+			}
+			decodeChain = strings.Join(segment.chain, " -> ")
+			originalIndex = originalSpan(matchIndex, segments)
+		}
 
 		// determine location of match. Note that the location
 		// in the finding will be the line/column numbers of the _match_
 		// not the _secret_, which will be different if the secretGroup
 		// value is set for this rule
-		loc := location(fragment, matchIndex)
+		loc := location(fragment, originalIndex)
 
-		if matchIndex[1] > loc.endLineIndex {
-			loc.endLineIndex = matchIndex[1]
+		if originalIndex[1] > loc.endLineIndex {
+			loc.endLineIndex = originalIndex[1]
 		}
 
 		finding := report.Finding{
@@ -283,11 +465,15 @@ func (d *Detector) detectRule(fragment Fragment, rule config.Rule) []report.Find
 			Secret:      secret,
 			Match:       secret,
 			Tags:        rule.Tags,
+			Severity:    rule.Severity,
+			Confidence:  rule.Confidence,
 			Line:        fragment.Raw[loc.startLineIndex:loc.endLineIndex],
+			DecodeChain: decodeChain,
 		}
+		markSuppressed(&finding, suppression)
 
-		if strings.Contains(fragment.Raw[loc.startLineIndex:loc.endLineIndex],
-			gitleaksAllowSignature) && !d.IgnoreGitleaksAllow {
+		if allow, ok := d.inlineAllowed(fragment, rule.RuleID, loc.startLine, loc.endLine); ok &&
+			d.suppress(rule, StageGitleaksAllow, &finding, "%s", allow) {
 			continue
 		}
 
@@ -305,6 +491,7 @@ func (d *Detector) detectRule(fragment Fragment, rule config.Rule) []report.Find
 		} else {
 			if len(groups) <= rule.SecretGroup || len(groups) == 0 {
 				// Config validation should prevent this
+				d.trace(rule, StageSecretGroup, &finding, "the match has no group %d", rule.SecretGroup)
 				continue
 			}
 			secret = groups[rule.SecretGroup]
@@ -312,29 +499,17 @@ func (d *Detector) detectRule(fragment Fragment, rule config.Rule) []report.Find
 		}
 
 		// check if the regexTarget is defined in the allowlist "regexes" entry
-		allowlistTarget := finding.Secret
-		switch rule.Allowlist.RegexTarget {
-		case "match":
-			allowlistTarget = finding.Match
-		case "line":
-			allowlistTarget = finding.Line
+		if d.regexAllowed(rule, &finding, allowlists) {
+			continue
 		}
 
-		globalAllowlistTarget := finding.Secret
-		switch d.Config.Allowlist.RegexTarget {
-		case "match":
-			globalAllowlistTarget = finding.Match
-		case "line":
-			globalAllowlistTarget = finding.Line
-		}
-		if rule.Allowlist.RegexAllowed(allowlistTarget) ||
-			d.Config.Allowlist.RegexAllowed(globalAllowlistTarget) {
+		// check if the secret is in the list of stopwords
+		if d.stopWordAllowed(rule, &finding, allowlists) {
 			continue
 		}
 
-		// check if the secret is in the list of stopwords
-		if rule.Allowlist.ContainsStopWord(finding.Secret) ||
-			d.Config.Allowlist.ContainsStopWord(finding.Secret) {
+		// check the allowlists whose criteria must all match
+		if d.allCriteriaAllowed(rule, fragment, &finding, allowlists) {
 			continue
 		}
 
@@ -342,7 +517,8 @@ func (d *Detector) detectRule(fragment Fragment, rule config.Rule) []report.Find
 		entropy := shannonEntropy(finding.Secret)
 		finding.Entropy = float32(entropy)
 		if rule.Entropy != 0.0 {
-			if entropy <= rule.Entropy {
+			if entropy <= rule.Entropy &&
+				d.suppress(rule, StageEntropy, &finding, "entropy %.2f is not above %g", entropy, rule.Entropy) {
 				// entropy is too low, skip this finding
 				continue
 			}
@@ -355,53 +531,125 @@ func (d *Detector) detectRule(fragment Fragment, rule config.Rule) []report.Find
 			// TODO: this should be replaced with stop words
 			if strings.HasPrefix(rule.RuleID, "generic") {
 				if !containsDigit(secret) {
+					d.trace(rule, StageGenericDigit, &finding, "the secret of a generic rule must contain a digit")
 					continue
 				}
 			}
 		}
 
+		// check the structure of the secret, ex: an embedded checksum
+		if rule.Validator != nil {
+			if !rule.Validator.Validate(finding.Secret) {
+				log.Trace().Msgf("skipping %s finding, secret failed %s validation", rule.RuleID, rule.Validator.Name)
+				d.trace(rule, StageValidator, &finding, "the secret failed %s validation", rule.Validator.Name)
+				continue
+			}
+			finding.StructurallyValid = true
+		}
+
+		// composite rules are only reported along with the rules they require
+		if len(rule.RequiredRules) > 0 {
+			auxiliaryMatches, ok := d.auxiliaryMatches(fragment, raw, segments, rule, matchIndex, loc)
+			if !ok {
+				d.trace(rule, StageRequired, &finding, "a required rule does not match close enough")
+				continue
+			}
+			finding.AuxiliaryMatches = auxiliaryMatches
+		}
+
 		findings = append(findings, finding)
 	}
 	return findings
 }
 
-// addFinding synchronously adds a finding to the findings slice
-func (d *Detector) addFinding(finding report.Finding) {
-	globalFingerprint := fmt.Sprintf("%s:%s:%d", finding.File, finding.RuleID, finding.StartLine)
-	if finding.Commit != "" {
-		finding.Fingerprint = fmt.Sprintf("%s:%s:%s:%d", finding.Commit, finding.File, finding.RuleID, finding.StartLine)
+// addFinding synchronously adds a finding to the findings slice, verifying
+// its secret until ctx is done.
+func (d *Detector) addFinding(ctx context.Context, finding report.Finding) {
+	if !d.keepFinding(&finding) {
+		return
+	}
+
+	// only the secrets which are not ignored are sent to be verified
+	d.verifyAndRedact(ctx, &finding)
+
+	d.findingMutex.Lock()
+	d.progress.Findings++
+	if d.OnFinding != nil {
+		d.OnFinding(finding)
 	} else {
-		finding.Fingerprint = globalFingerprint
+		d.findings = append(d.findings, finding)
 	}
+	if d.Verbose {
+		printFinding(finding, d.NoColor)
+	}
+	d.findingMutex.Unlock()
+}
+
+// keepFinding sets the fingerprint of a finding, located in its file, and
+// returns whether it is reported: it is neither ignored by its fingerprint nor
+// in the baseline, or it is marked suppressed if suppressed findings are
+// included.
+func (d *Detector) keepFinding(finding *report.Finding) bool {
+	finding.Fingerprint = fingerprint(*finding)
+	rule := d.Config.Rules[finding.RuleID]
 
 	// check if we should ignore this finding
-	if _, ok := d.gitleaksIgnore[globalFingerprint]; ok {
-		log.Debug().Msgf("ignoring finding with global Fingerprint %s",
-			finding.Fingerprint)
-		return
-	} else if finding.Commit != "" {
-		// Awkward nested if because I'm not sure how to chain these two conditions.
-		if _, ok := d.gitleaksIgnore[finding.Fingerprint]; ok {
-			log.Debug().Msgf("ignoring finding with Fingerprint %s",
-				finding.Fingerprint)
-			return
+	if e := d.gitleaksIgnore.matchFingerprint(*finding); e != nil {
+		if !d.IncludeSuppressed {
+			log.Debug().Msgf("ignoring finding with Fingerprint %s, %s", finding.Fingerprint, e)
+			d.trace(rule, StageGitleaksIgnore, finding, "%s ignores the finding", e)
+			return false
 		}
+		markSuppressed(finding, ignoreReason(e))
 	}
 
-	if d.baseline != nil && !IsNew(finding, d.baseline) {
-		log.Debug().Msgf("baseline duplicate -- ignoring finding with Fingerprint %s", finding.Fingerprint)
-		return
+	// baselines hold the findings as reported, redacted or not
+	redacted := *finding
+	if d.Redact > 0 {
+		redacted.Redact(d.Redact)
 	}
+	if d.baseline != nil && !IsNew(redacted, d.baseline) {
+		reason := fmt.Sprintf("the finding is in the baseline %s", d.baselinePath)
+		if !d.IncludeSuppressed {
+			log.Debug().Msgf("baseline duplicate -- ignoring finding with Fingerprint %s", finding.Fingerprint)
+			d.trace(rule, StageBaseline, finding, reason)
+			return false
+		}
+		markSuppressed(finding, suppressionReason(StageBaseline, reason))
+	}
+	return true
+}
 
-	d.findingMutex.Lock()
-	d.findings = append(d.findings, finding)
-	if d.Verbose {
-		printFinding(finding, d.NoColor)
+// fingerprint returns the fingerprint identifying a finding in
+// .gitleaksignore files.
+func fingerprint(finding report.Finding) string {
+	if finding.Commit != "" {
+		return fmt.Sprintf("%s:%s:%s:%d", finding.Commit, finding.File, finding.RuleID, finding.StartLine)
+	} else if finding.Layer != "" {
+		return fmt.Sprintf("%s:%s:%s:%d", finding.Layer, finding.File, finding.RuleID, finding.StartLine)
 	}
-	d.findingMutex.Unlock()
+	return globalFingerprint(finding)
 }
 
-// addCommit synchronously adds a commit to the commit slice
+// globalFingerprint returns the fingerprint of a finding without its commit
+// or layer, which ignores the finding in every commit or layer.
+func globalFingerprint(finding report.Finding) string {
+	return fmt.Sprintf("%s:%s:%d", finding.File, finding.RuleID, finding.StartLine)
+}
+
+// addCommit synchronously adds a commit to the commit slice, along with one
+// of the files it changes
 func (d *Detector) addCommit(commit string) {
-	d.commitMap[commit] = true
+	d.findingMutex.Lock()
+	if !d.commitMap[commit] {
+		d.commitMap[commit] = true
+		d.progress.Commits++
+		if commit != "" {
+			d.commitOrder = append(d.commitOrder, commit)
+		}
+	}
+	if commit != "" {
+		d.pendingFiles[commit]++
+	}
+	d.findingMutex.Unlock()
 }
@@ -1,6 +1,7 @@
 package detect
 
 import (
+	"context"
 	"io"
 	"os"
 	"strings"
@@ -12,9 +13,31 @@ import (
 )
 
 func (d *Detector) DetectFiles(paths <-chan sources.ScanTarget) ([]report.Finding, error) {
+	return d.DetectFilesContext(context.Background(), paths)
+}
+
+// DetectFilesContext is DetectFiles stopping once ctx is done, the findings
+// found so far are then returned with a PartialScanError. The paths left in
+// paths are discarded, pass the targets of sources.DirectoryTargetsContext to
+// stop the walk of the directory too.
+func (d *Detector) DetectFilesContext(ctx context.Context, paths <-chan sources.ScanTarget) ([]report.Finding, error) {
+	add := func(finding report.Finding) {
+		d.addFinding(ctx, finding)
+	}
 	for pa := range paths {
+		if ctx.Err() != nil {
+			go func() {
+				for range paths {
+				}
+			}()
+			break
+		}
 		p := pa
 		d.Sema.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			defer d.fileScanned()
 
 			f, err := os.Open(p.Path)
 			if err != nil {
@@ -36,52 +59,59 @@ func (d *Detector) DetectFiles(paths <-chan sources.ScanTarget) ([]report.Findin
 				}
 			}
 
-			// Buffer to hold file chunks
-			buf := make([]byte, chunkSize)
-			totalLines := 0
-			for {
-				n, err := f.Read(buf)
-				if err != nil && err != io.EOF {
-					return err
-				}
-				if n == 0 {
-					break
-				}
-
-				// TODO: optimization could be introduced here
-				mimetype, err := filetype.Match(buf[:n])
-				if err != nil {
-					return err
-				}
-				if mimetype.MIME.Type == "application" {
-					return nil // skip binary files
-				}
-
-				// Count the number of newlines in this chunk
-				linesInChunk := strings.Count(string(buf[:n]), "\n")
-				totalLines += linesInChunk
-				fragment := Fragment{
-					Raw:      string(buf[:n]),
-					FilePath: p.Path,
-				}
-				if p.Symlink != "" {
-					fragment.SymlinkFile = p.Symlink
-				}
-				for _, finding := range d.Detect(fragment) {
-					// need to add 1 since line counting starts at 1
-					finding.StartLine += (totalLines - linesInChunk) + 1
-					finding.EndLine += (totalLines - linesInChunk) + 1
-					d.addFinding(finding)
-				}
-			}
-
-			return nil
+			return d.detectContent(ctx, f, p.Path, p.Symlink, 0, add)
 		})
 	}
 
-	if err := d.Sema.Wait(); err != nil {
-		return d.findings, err
+	err := d.Sema.Wait()
+	if partialErr := d.partialScan(ctx); partialErr != nil {
+		// the walk of sources.DirectoryTargetsContext fails with the error
+		// of ctx
+		return d.findings, partialErr
 	}
+	return d.findings, err
+}
+
+// detectChunks reads r in chunks and scans each chunk as a fragment. Line
+// numbers of the findings passed to add are relative to the start of r.
+// Binary content is skipped if skipBinary is true.
+func (d *Detector) detectChunks(r io.Reader, path string, symlink string, skipBinary bool, add func(report.Finding)) error {
+	// Buffer to hold file chunks
+	buf := make([]byte, chunkSize)
+	totalLines := 0
+	for {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		if n == 0 {
+			break
+		}
 
-	return d.findings, nil
+		// TODO: optimization could be introduced here
+		mimetype, err := filetype.Match(buf[:n])
+		if err != nil {
+			return err
+		}
+		if skipBinary && mimetype.MIME.Type == "application" {
+			return nil // skip binary files
+		}
+
+		// Count the number of newlines in this chunk
+		linesInChunk := strings.Count(string(buf[:n]), "\n")
+		totalLines += linesInChunk
+		fragment := Fragment{
+			Raw:      string(buf[:n]),
+			FilePath: path,
+		}
+		if symlink != "" {
+			fragment.SymlinkFile = symlink
+		}
+		for _, finding := range d.detect(fragment) {
+			// need to add 1 since line counting starts at 1
+			shiftLines(&finding, (totalLines-linesInChunk)+1)
+			add(finding)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,206 @@
+package detect
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+// Stage is a step of the detection which decides whether a rule reports a
+// finding.
+type Stage string
+
+const (
+	StagePathAllowlist   Stage = "path allowlist"
+	StageCommitAllowlist Stage = "commit allowlist"
+	StageKeywords        Stage = "keywords"
+	StagePath            Stage = "path"
+	StageSize            Stage = "size"
+	StageRegex           Stage = "regex"
+	StageGitleaksAllow   Stage = "gitleaks:allow"
+	StageSecretGroup     Stage = "secret group"
+	StageRegexAllowlist  Stage = "regex allowlist"
+	StageStopWords       Stage = "stop words"
+	StageEntropy         Stage = "entropy"
+	StageGenericDigit    Stage = "generic digit"
+	StageValidator       Stage = "validator"
+	StageRequired        Stage = "required rules"
+	StageFilter          Stage = "filter"
+	StageGitleaksIgnore  Stage = ".gitleaksignore"
+	StageBaseline        Stage = "baseline"
+	StageReported        Stage = "reported"
+)
+
+// Decision is the outcome of a stage of the detection for a rule: either the
+// rule was dropped, or one of its matches, or a finding was reported.
+type Decision struct {
+	RuleID string
+	Stage  Stage
+
+	// Reason names the allowlist entry, threshold or rule responsible for
+	// the decision.
+	Reason string
+
+	// Finding is the match the decision is about. It is nil if the rule was
+	// dropped before matching the content.
+	Finding *report.Finding
+}
+
+// Explain scans a fragment like Detect and returns why every rule of the
+// config did or did not report a finding. Decisions about matches on other
+// lines than line are left out, unless line is 0. Lines are counted from 1.
+// The fragment is explained with the config applying to its path, see
+// ConfigPath.
+func (d *Detector) Explain(fragment Fragment, line int) []Decision {
+	var decisions []Decision
+	if nc := d.nestedConfigFor(fragment.FilePath); nc != nil {
+		d = nc.detector(d)
+	}
+	e := *d
+	e.IncludeSuppressed = false
+	// the findings are located in their file, like the findings of a
+	// directory scan, once they are checked against their fingerprint
+	lineOffset := 0
+	e.explain = func(decision Decision) {
+		if decision.Finding != nil {
+			shiftLines(decision.Finding, -lineOffset)
+		}
+		decisions = append(decisions, decision)
+	}
+
+	if reason := e.pathIgnored(fragment.FilePath); reason != "" {
+		for _, rule := range e.Config.GetOrderedRules() {
+			e.trace(rule, StagePathAllowlist, nil, reason)
+		}
+		return decisions
+	}
+
+	findings := e.fragmentFindings(e.detectFragment(fragment))
+	lineOffset = 1
+	for _, f := range findings {
+		shiftLines(&f, lineOffset)
+		if e.keepFinding(&f) {
+			e.trace(e.Config.Rules[f.RuleID], StageReported, &f, "")
+		}
+	}
+
+	return e.explained(decisions, line)
+}
+
+// explained shifts the lines of decisions to count from 1, keeps the ones
+// about line and returns them grouped by rule, in the order of the rules.
+// Rules dropped before matching at some decode depth are only explained by
+// the first decision about them if none of their matches are.
+func (d *Detector) explained(decisions []Decision, line int) []Decision {
+	byRule := make(map[string][]Decision)
+	for _, decision := range decisions {
+		rule := d.Config.Rules[decision.RuleID]
+		if f := decision.Finding; f != nil {
+			shiftLines(f, 1)
+			if line > 0 && rule.Regex != nil && (line < f.StartLine || line > f.EndLine) {
+				continue
+			}
+			if d.Redact > 0 {
+				f.Redact(d.Redact)
+			}
+		}
+		byRule[decision.RuleID] = append(byRule[decision.RuleID], decision)
+	}
+
+	var explained []Decision
+	for _, rule := range d.Config.GetOrderedRules() {
+		var matches []Decision
+		for _, decision := range byRule[rule.RuleID] {
+			if decision.Finding != nil {
+				matches = append(matches, decision)
+			}
+		}
+		switch {
+		case len(matches) > 0:
+			sort.SliceStable(matches, func(i, j int) bool {
+				a, b := matches[i].Finding, matches[j].Finding
+				return a.StartLine < b.StartLine || (a.StartLine == b.StartLine && a.StartColumn < b.StartColumn)
+			})
+			explained = append(explained, matches...)
+		case len(byRule[rule.RuleID]) > 0:
+			explained = append(explained, byRule[rule.RuleID][0])
+		default:
+			reason := "the regex does not match"
+			if line > 0 {
+				reason = fmt.Sprintf("the regex does not match line %d", line)
+			}
+			explained = append(explained, Decision{RuleID: rule.RuleID, Stage: StageRegex, Reason: reason})
+		}
+	}
+	return explained
+}
+
+// pathIgnored returns why no rule is run against the file at path, or an
+// empty string if the rules are run.
+func (d *Detector) pathIgnored(path string) string {
+	stage, reason := d.globalFragmentMatch(Fragment{FilePath: path})
+	switch {
+	case path == "":
+		return ""
+	case stage == StagePathAllowlist:
+		return reason
+	case path == d.Config.Path:
+		return fmt.Sprintf("%s is the config", path)
+	case d.baselinePath != "" && path == d.baselinePath:
+		return fmt.Sprintf("%s is the baseline", path)
+	}
+	return ""
+}
+
+// trace passes the decision of a stage about a rule, and the match it is
+// about if any, to the detector explaining a fragment.
+func (d *Detector) trace(rule config.Rule, stage Stage, finding *report.Finding, format string, args ...interface{}) {
+	if d.explain == nil {
+		return
+	}
+	decision := Decision{
+		RuleID: rule.RuleID,
+		Stage:  stage,
+		Reason: fmt.Sprintf(format, args...),
+	}
+	if finding != nil {
+		f := *finding
+		f.AuxiliaryMatches = append([]report.AuxiliaryMatch(nil), f.AuxiliaryMatches...)
+		decision.Finding = &f
+	}
+	d.explain(decision)
+}
+
+// matchingRegex returns the first regex matching s.
+func matchingRegex(res []*regexp.Regexp, s string) string {
+	for _, re := range res {
+		if re.MatchString(s) {
+			return re.String()
+		}
+	}
+	return ""
+}
+
+// matchingStopWord returns the first stop word contained in s.
+func matchingStopWord(stopWords []string, s string) string {
+	s = strings.ToLower(s)
+	for _, stopWord := range stopWords {
+		if strings.Contains(s, strings.ToLower(stopWord)) {
+			return stopWord
+		}
+	}
+	return ""
+}
+
+// regexTargetName names the part of a finding an allowlist regex is tested
+// against.
+func regexTargetName(regexTarget string) string {
+	if regexTarget == "" {
+		return "secret"
+	}
+	return regexTarget
+}
@@ -1,6 +1,8 @@
 package detect
 
 import (
+	"context"
+
 	"github.com/gitleaks/go-gitdiff/gitdiff"
 	"github.com/rs/zerolog/log"
 	"github.com/zricethezav/gitleaks/v8/report"
@@ -8,16 +10,31 @@ import (
 )
 
 func (d *Detector) DetectGit(gitCmd *sources.GitCmd) ([]report.Finding, error) {
+	return d.DetectGitContext(context.Background(), gitCmd)
+}
+
+// DetectGitContext is DetectGit stopping once ctx is done: the git process is
+// killed and the findings found so far are returned with a
+// PartialScanError.
+func (d *Detector) DetectGitContext(ctx context.Context, gitCmd *sources.GitCmd) ([]report.Finding, error) {
 	defer gitCmd.Wait()
 	diffFilesCh := gitCmd.DiffFilesCh()
 	errCh := gitCmd.ErrCh()
+	listed := false
 
 	// loop to range over both DiffFiles (stdout) and ErrCh (stderr)
 	for diffFilesCh != nil || errCh != nil {
 		select {
+		case <-ctx.Done():
+			if err := gitCmd.Kill(); err != nil {
+				log.Debug().Err(err).Msg("could not kill git")
+			}
+			drainGitCmd(diffFilesCh, errCh)
+			diffFilesCh, errCh = nil, nil
 		case gitdiffFile, open := <-diffFilesCh:
 			if !open {
 				diffFilesCh = nil
+				listed = true
 				break
 			}
 
@@ -30,17 +47,25 @@ func (d *Detector) DetectGit(gitCmd *sources.GitCmd) ([]report.Finding, error) {
 			commitSHA := ""
 			if gitdiffFile.PatchHeader != nil {
 				commitSHA = gitdiffFile.PatchHeader.SHA
-				if d.Config.Allowlist.CommitAllowed(gitdiffFile.PatchHeader.SHA) {
+				if d.commitAllowed(gitdiffFile.PatchHeader.SHA) {
 					continue
 				}
 			}
 			d.addCommit(commitSHA)
 
 			d.Sema.Go(func() error {
+				if ctx.Err() != nil {
+					return nil
+				}
+				complete := false
+				defer func() { d.commitFileScanned(commitSHA, complete) }()
 				for _, textFragment := range gitdiffFile.TextFragments {
-					if textFragment == nil {
+					if ctx.Err() != nil {
 						return nil
 					}
+					if textFragment == nil {
+						break
+					}
 
 					fragment := Fragment{
 						Raw:       textFragment.Raw(gitdiff.OpAdd),
@@ -48,10 +73,11 @@ func (d *Detector) DetectGit(gitCmd *sources.GitCmd) ([]report.Finding, error) {
 						FilePath:  gitdiffFile.NewName,
 					}
 
-					for _, finding := range d.Detect(fragment) {
-						d.addFinding(augmentGitFinding(finding, textFragment, gitdiffFile))
+					for _, finding := range d.detect(fragment) {
+						d.addFinding(ctx, augmentGitFinding(finding, textFragment, gitdiffFile))
 					}
 				}
+				complete = true
 				return nil
 			})
 		case err, open := <-errCh:
@@ -67,7 +93,26 @@ func (d *Detector) DetectGit(gitCmd *sources.GitCmd) ([]report.Finding, error) {
 	if err := d.Sema.Wait(); err != nil {
 		return d.findings, err
 	}
+	d.findingMutex.Lock()
+	d.advanceCommits(listed)
+	d.findingMutex.Unlock()
+	if err := d.partialScan(ctx); err != nil {
+		return d.findings, err
+	}
 	log.Info().Msgf("%d commits scanned.", len(d.commitMap))
 	log.Debug().Msg("Note: this number might be smaller than expected due to commits with no additions")
 	return d.findings, nil
 }
+
+// drainGitCmd discards the rest of the output of a killed git process so the
+// goroutines reading it can exit.
+func drainGitCmd(diffFilesCh <-chan *gitdiff.File, errCh <-chan error) {
+	go func() {
+		for range diffFilesCh {
+		}
+	}()
+	go func() {
+		for range errCh {
+		}
+	}()
+}
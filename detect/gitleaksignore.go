@@ -0,0 +1,283 @@
+package detect
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+// gitleaksIgnore holds the entries of the .gitleaksignore files of a detector.
+// An entry is one of:
+//
+//   - a fingerprint, [commit:]file:rule:line, ignoring a finding at a location,
+//   - a fingerprint with * (no /), ** and ? globs, ex: testdata/**:*:*
+//   - rule:<id>, ignoring every finding of the rules matching the glob <id>,
+//   - sha256:<hash>, ignoring the findings of a secret in every commit and file.
+//
+// Entries can be followed by expires=YYYY-MM-DD and owner=<owner>, and lines
+// starting with # and text following " #" are comments.
+type gitleaksIgnore struct {
+	// paths are the absolute paths of the loaded files
+	paths map[string]bool
+
+	fingerprints map[string]*ignoreEntry
+	patterns     []*ignoreEntry
+	rules        []*ignoreEntry
+	secrets      map[string]*ignoreEntry
+
+	// entries are all the entries in the order they were loaded
+	entries []*ignoreEntry
+}
+
+// ignoreEntry is an entry of a .gitleaksignore file.
+type ignoreEntry struct {
+	// source is the file and line of the entry, ex: .gitleaksignore:3
+	source string
+
+	entry   string
+	owner   string
+	expires time.Time
+
+	// pattern matches the fingerprints or rule IDs of glob entries
+	pattern *regexp.Regexp
+
+	used atomic.Bool
+}
+
+func newGitleaksIgnore() *gitleaksIgnore {
+	return &gitleaksIgnore{
+		paths:        make(map[string]bool),
+		fingerprints: make(map[string]*ignoreEntry),
+		secrets:      make(map[string]*ignoreEntry),
+	}
+}
+
+func (d *Detector) AddGitleaksIgnore(gitleaksIgnorePath string) error {
+	// the same file is often found both at --gitleaks-ignore-path and in the
+	// source
+	if abs, err := filepath.Abs(gitleaksIgnorePath); err == nil {
+		if d.gitleaksIgnore.paths[abs] {
+			return nil
+		}
+		d.gitleaksIgnore.paths[abs] = true
+	}
+
+	log.Debug().Msgf("found .gitleaksignore file: %s", gitleaksIgnorePath)
+	file, err := os.Open(gitleaksIgnorePath)
+
+	if err != nil {
+		return err
+	}
+
+	// https://github.com/securego/gosec/issues/512
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Warn().Msgf("Error closing .gitleaksignore file: %s\n", err)
+		}
+	}()
+	scanner := bufio.NewScanner(file)
+
+	for n := 1; scanner.Scan(); n++ {
+		if err := d.gitleaksIgnore.add(fmt.Sprintf("%s:%d", gitleaksIgnorePath, n), scanner.Text(), time.Now()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+var (
+	// ignoreComment starts the comment of a .gitleaksignore line: a # between
+	// spaces, which fingerprints of paths with a # in them rarely hold.
+	ignoreComment = regexp.MustCompile(`\s#(\s|$)`)
+
+	// ignoreAttribute matches the attributes following an entry.
+	ignoreAttribute = regexp.MustCompile(`^(expires|owner)=`)
+)
+
+// add parses a line of a .gitleaksignore file. Entries which expired before
+// now are left out.
+func (g *gitleaksIgnore) add(source, line string, now time.Time) error {
+	if loc := ignoreComment.FindStringIndex(line); loc != nil {
+		line = line[:loc[0]]
+	}
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	// the entry is the line up to its trailing attributes, the paths of
+	// fingerprints may hold spaces
+	e := &ignoreEntry{source: source}
+	for {
+		i := strings.LastIndexAny(line, " \t")
+		if i < 0 || !ignoreAttribute.MatchString(line[i+1:]) {
+			break
+		}
+		key, value, _ := strings.Cut(line[i+1:], "=")
+		line = strings.TrimSpace(line[:i])
+		switch key {
+		case "owner":
+			e.owner = value
+		case "expires":
+			expires, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return fmt.Errorf("%s: expires must be a YYYY-MM-DD date, got %q", source, value)
+			}
+			e.expires = expires
+		}
+	}
+	e.entry = line
+	if !e.expires.IsZero() && !now.Before(e.expires.AddDate(0, 0, 1)) {
+		log.Warn().Msgf("%s expired on %s and is no longer used", e, e.expires.Format("2006-01-02"))
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(e.entry, "sha256:"):
+		hash := strings.ToLower(strings.TrimPrefix(e.entry, "sha256:"))
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != 2*sha256.Size {
+			return fmt.Errorf("%s: %q is not a sha256 hash", source, hash)
+		}
+		g.secrets[hash] = e
+	case strings.HasPrefix(e.entry, "rule:"):
+		e.pattern = globRegex(strings.TrimPrefix(e.entry, "rule:"))
+		g.rules = append(g.rules, e)
+	case strings.ContainsAny(e.entry, "*?"):
+		e.pattern = globRegex(e.entry)
+		g.patterns = append(g.patterns, e)
+	default:
+		g.fingerprints[e.entry] = e
+	}
+	g.entries = append(g.entries, e)
+	return nil
+}
+
+// matchSecret returns the entry ignoring a finding whatever its location: a
+// rule or a secret hash entry.
+func (g *gitleaksIgnore) matchSecret(finding report.Finding) *ignoreEntry {
+	if len(g.secrets) > 0 {
+		if e, ok := g.secrets[secretHash(finding.Secret)]; ok {
+			return e.use()
+		}
+	}
+	for _, e := range g.rules {
+		if e.pattern.MatchString(finding.RuleID) {
+			return e.use()
+		}
+	}
+	return nil
+}
+
+// matchFingerprint returns the entry matching the fingerprint, or the global
+// fingerprint, of a finding.
+func (g *gitleaksIgnore) matchFingerprint(finding report.Finding) *ignoreEntry {
+	global, fp := globalFingerprint(finding), fingerprint(finding)
+	if e, ok := g.fingerprints[global]; ok {
+		return e.use()
+	}
+	if e, ok := g.fingerprints[fp]; ok {
+		return e.use()
+	}
+	for _, e := range g.patterns {
+		if e.pattern.MatchString(global) || e.pattern.MatchString(fp) {
+			return e.use()
+		}
+	}
+	return nil
+}
+
+// unused returns the entries which did not match any finding.
+func (g *gitleaksIgnore) unused() []string {
+	var unused []string
+	for _, e := range g.entries {
+		if !e.used.Load() {
+			unused = append(unused, e.String())
+		}
+	}
+	return unused
+}
+
+func (e *ignoreEntry) use() *ignoreEntry {
+	e.used.Store(true)
+	return e
+}
+
+func (e *ignoreEntry) String() string {
+	s := fmt.Sprintf("%s %q", e.source, e.entry)
+	if e.owner != "" {
+		s += " owned by " + e.owner
+	}
+	return s
+}
+
+// UnusedGitleaksIgnoreEntries returns the .gitleaksignore entries which did
+// not match any finding so far, so they can be pruned.
+func (d *Detector) UnusedGitleaksIgnoreEntries() []string {
+	return d.gitleaksIgnore.unused()
+}
+
+// ignoreSecrets drops the findings ignored by rule or secret hash entries, or
+// marks them as suppressed if suppressed findings are included. These entries
+// do not depend on the location of a finding so they are checked before the
+// secrets are redacted.
+func (d *Detector) ignoreSecrets(findings []report.Finding) []report.Finding {
+	if len(d.gitleaksIgnore.rules) == 0 && len(d.gitleaksIgnore.secrets) == 0 {
+		return findings
+	}
+	var retFindings []report.Finding
+	for _, f := range findings {
+		if e := d.gitleaksIgnore.matchSecret(f); e != nil {
+			if !d.IncludeSuppressed {
+				log.Debug().Msgf("ignoring %s finding, %s", f.RuleID, e)
+				d.trace(d.Config.Rules[f.RuleID], StageGitleaksIgnore, &f, "%s ignores the finding", e)
+				continue
+			}
+			markSuppressed(&f, ignoreReason(e))
+		}
+		retFindings = append(retFindings, f)
+	}
+	return retFindings
+}
+
+// ignoreReason returns the SuppressionReason of a finding ignored by e.
+func ignoreReason(e *ignoreEntry) string {
+	return suppressionReason(StageGitleaksIgnore, fmt.Sprintf("%s ignores the finding", e))
+}
+
+func secretHash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// globRegex converts a glob to a regex: ** matches anything, * anything but /
+// and ? any character but /.
+func globRegex(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		case glob[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,114 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+func TestGitleaksIgnore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitleaksignore")
+	require.NoError(t, os.WriteFile(path, []byte(`# gitleaksignore v2
+api/config.go:aws-access-key:20
+53cd7a3c6eb4937f413e3c25e4a9f39289afa69e:api/commit.go:aws-access-key:20 # rotated
+testdata/**:*:*  owner=@security
+rule:generic-*   expires=2099-01-01
+sha256:`+secretHash("hunter2")+`  owner=@alice
+old.go:aws-access-key:1 expires=2000-01-01
+never/used.go:aws-access-key:1
+my dir/a.txt:aws-access-key:1
+issue #12/a.txt:aws-access-key:1 owner=@bob # spaces and # in paths
+`), 0600))
+
+	d := NewDetector(config.Config{})
+	require.NoError(t, d.AddGitleaksIgnore(path))
+	// loading the same file twice does not duplicate its entries
+	require.NoError(t, d.AddGitleaksIgnore(path))
+
+	tests := []struct {
+		name     string
+		finding  report.Finding
+		expected string
+	}{
+		{
+			name:     "global fingerprint",
+			finding:  report.Finding{RuleID: "aws-access-key", File: "api/config.go", StartLine: 20, Commit: "abc"},
+			expected: "api/config.go:aws-access-key:20",
+		},
+		{
+			name:     "commit fingerprint",
+			finding:  report.Finding{RuleID: "aws-access-key", File: "api/commit.go", StartLine: 20, Commit: "53cd7a3c6eb4937f413e3c25e4a9f39289afa69e"},
+			expected: "53cd7a3c6eb4937f413e3c25e4a9f39289afa69e:api/commit.go:aws-access-key:20",
+		},
+		{
+			name:    "other commit",
+			finding: report.Finding{RuleID: "aws-access-key", File: "api/commit.go", StartLine: 20, Commit: "abc"},
+		},
+		{
+			name:     "glob",
+			finding:  report.Finding{RuleID: "aws-access-key", File: "testdata/repos/app.go", StartLine: 3},
+			expected: "testdata/**:*:*",
+		},
+		{
+			name:     "rule",
+			finding:  report.Finding{RuleID: "generic-api-key", File: "app.go", StartLine: 3},
+			expected: "rule:generic-*",
+		},
+		{
+			name:     "secret hash",
+			finding:  report.Finding{RuleID: "password", File: "moved.go", StartLine: 42, Secret: "hunter2"},
+			expected: "sha256:" + secretHash("hunter2"),
+		},
+		{
+			name:     "space in path",
+			finding:  report.Finding{RuleID: "aws-access-key", File: "my dir/a.txt", StartLine: 1},
+			expected: "my dir/a.txt:aws-access-key:1",
+		},
+		{
+			name:     "# in path",
+			finding:  report.Finding{RuleID: "aws-access-key", File: "issue #12/a.txt", StartLine: 1},
+			expected: "issue #12/a.txt:aws-access-key:1",
+		},
+		{
+			name:    "expired",
+			finding: report.Finding{RuleID: "aws-access-key", File: "old.go", StartLine: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := d.gitleaksIgnore.matchSecret(tt.finding)
+			if e == nil {
+				e = d.gitleaksIgnore.matchFingerprint(tt.finding)
+			}
+			if tt.expected == "" {
+				assert.Nil(t, e)
+				return
+			}
+			require.NotNil(t, e)
+			assert.Equal(t, tt.expected, e.entry)
+		})
+	}
+
+	assert.Equal(t, []string{path + `:8 "never/used.go:aws-access-key:1"`}, d.UnusedGitleaksIgnoreEntries())
+}
+
+func TestGitleaksIgnoreErrors(t *testing.T) {
+	g := newGitleaksIgnore()
+	now := time.Now()
+	assert.EqualError(t, g.add(".gitleaksignore:1", "sha256:abc", now),
+		`.gitleaksignore:1: "abc" is not a sha256 hash`)
+	assert.EqualError(t, g.add(".gitleaksignore:2", "a.go:rule:1 expires=tomorrow", now),
+		`.gitleaksignore:2: expires must be a YYYY-MM-DD date, got "tomorrow"`)
+
+	// only expires= and owner= are attributes, other tokens are part of the
+	// entry
+	require.NoError(t, g.add(".gitleaksignore:3", "a.go:rule:1 team=x", now))
+	assert.Contains(t, g.fingerprints, "a.go:rule:1 team=x")
+}
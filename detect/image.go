@@ -0,0 +1,116 @@
+package detect
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/zricethezav/gitleaks/v8/report"
+	"github.com/zricethezav/gitleaks/v8/sources"
+)
+
+const (
+	// file names reported for findings in the image config, where
+	// secrets passed in with `ENV` and `ARG` usually end up
+	imageEnvFile     = "Env"
+	imageHistoryFile = "History"
+
+	// whiteoutPrefix marks files deleted in a layer
+	whiteoutPrefix = ".wh."
+)
+
+// DetectImage scans the config and every layer of a container image. Files
+// deleted by a later layer are still scanned in the layer that added them.
+func (d *Detector) DetectImage(img *sources.Image) ([]report.Finding, error) {
+	return d.DetectImageContext(context.Background(), img)
+}
+
+// DetectImageContext is DetectImage stopping once ctx is done, the findings
+// found so far are then returned with a PartialScanError.
+func (d *Detector) DetectImageContext(ctx context.Context, img *sources.Image) ([]report.Finding, error) {
+	defer img.Close()
+
+	// the image config entries are scanned one per line so the
+	// line number of a finding is the index of the entry
+	for _, c := range img.Configs {
+		d.detectImageConfig(ctx, c.Env, imageEnvFile, c.Digest)
+		d.detectImageConfig(ctx, c.History, imageHistoryFile, c.Digest)
+	}
+
+	for _, layer := range img.Layers {
+		if err := d.detectLayer(ctx, layer); err != nil {
+			return d.findings, err
+		}
+	}
+	return d.findings, d.partialScan(ctx)
+}
+
+func (d *Detector) detectImageConfig(ctx context.Context, entries []string, file string, digest string) {
+	if len(entries) == 0 {
+		return
+	}
+	fragment := Fragment{
+		Raw:      strings.Join(entries, "\n") + "\n",
+		FilePath: file,
+	}
+	for _, finding := range d.detect(fragment) {
+		shiftLines(&finding, 1)
+		finding.Layer = digest
+		d.addFinding(ctx, finding)
+	}
+}
+
+func (d *Detector) detectLayer(ctx context.Context, layer sources.ImageLayer) error {
+	log.Debug().Msgf("scanning layer: %s", layer.Digest)
+	rc, err := layer.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	add := func(finding report.Finding) {
+		finding.Layer = layer.Digest
+		d.addFinding(ctx, finding)
+	}
+
+	tr := tar.NewReader(rc)
+	for {
+		if err := d.partialScan(ctx); err != nil {
+			return err
+		}
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// image paths are reported as absolute paths
+		filePath := path.Clean("/" + header.Name)
+		if strings.HasPrefix(path.Base(filePath), whiteoutPrefix) {
+			log.Debug().Msgf("%s deleted in layer %s", strings.Replace(filePath, whiteoutPrefix, "", 1), layer.Digest)
+			continue
+		}
+		if header.Typeflag != tar.TypeReg || header.Size == 0 {
+			continue
+		}
+		if d.MaxTargetMegaBytes > 0 {
+			rawLength := header.Size / 1000000
+			if rawLength > int64(d.MaxTargetMegaBytes) {
+				log.Debug().Msgf("skipping file: %s scan due to size: %d", filePath, rawLength)
+				d.fileScanned()
+				continue
+			}
+		}
+		err = d.detectContent(ctx, tr, filePath, "", 0, add)
+		d.fileScanned()
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package detect
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	ahocorasick "github.com/BobuSumisu/aho-corasick"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+)
+
+// nestedConfigName is the name of the config files applying to the
+// directory they are in.
+const nestedConfigName = ".gitleaks.toml"
+
+// nestedConfig is the config of a .gitleaks.toml file found in a
+// subdirectory of the source.
+type nestedConfig struct {
+	// path is the path of the .gitleaks.toml file
+	path string
+
+	config    config.Config
+	prefilter ahocorasick.Trie
+}
+
+// AddNestedConfigs loads the .gitleaks.toml files found in the subdirectories
+// of source. Each one extends the config of its parent directory, the config
+// of the detector for the topmost ones, and applies to the fragments whose
+// FilePath is under its directory, relative to source or starting with it.
+// prepare, if not nil, is called with every nested config before it is used,
+// ex: to select its rules.
+func (d *Detector) AddNestedConfigs(source string, prepare func(cfg *config.Config)) error {
+	var dirs []string
+	err := filepath.WalkDir(source, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if entry.IsDir() || entry.Name() != nestedConfigName {
+			return nil
+		}
+		dir, err := filepath.Rel(source, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		if dir != "." {
+			dirs = append(dirs, filepath.ToSlash(dir))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// parent directories sort before their subdirectories
+	sort.Strings(dirs)
+	if d.nestedConfigs == nil {
+		d.nestedConfigs = make(map[string]*nestedConfig, len(dirs))
+	}
+	d.nestedRoot = source
+	for _, dir := range dirs {
+		configPath := filepath.Join(source, filepath.FromSlash(dir), nestedConfigName)
+		parent, parentPath := d.Config, d.Config.Path
+		if nc := d.nearestNestedConfig(path.Dir(dir)); nc != nil {
+			parent, parentPath = nc.config, nc.path
+		}
+
+		v := viper.New()
+		v.SetConfigFile(configPath)
+		v.SetConfigType("toml")
+		if err := v.ReadInConfig(); err != nil {
+			return fmt.Errorf("could not read nested config %s: %w", configPath, err)
+		}
+		var vc config.ViperConfig
+		if err := v.Unmarshal(&vc); err != nil {
+			return fmt.Errorf("could not read nested config %s: %w", configPath, err)
+		}
+		cfg, err := vc.TranslateNested(parent, parentPath)
+		if err != nil {
+			return fmt.Errorf("could not load nested config %s: %w", configPath, err)
+		}
+		cfg.Path = configPath
+		if prepare != nil {
+			prepare(&cfg)
+		}
+
+		d.nestedConfigs[dir] = &nestedConfig{
+			path:      configPath,
+			config:    cfg,
+			prefilter: *ahocorasick.NewTrieBuilder().AddStrings(cfg.Keywords).Build(),
+		}
+		log.Debug().Msgf("applying %s, extending %s, to %s", configPath, parentPath, dir)
+	}
+	return nil
+}
+
+// ConfigPath returns the path of the config applying to the file at
+// filePath: a nested config, see AddNestedConfigs, or the config of the
+// detector.
+func (d *Detector) ConfigPath(filePath string) string {
+	if nc := d.nestedConfigFor(filePath); nc != nil {
+		return nc.path
+	}
+	return d.Config.Path
+}
+
+// nestedConfigFor returns the nested config applying to the file at
+// filePath, or nil if the config of the detector applies.
+func (d *Detector) nestedConfigFor(filePath string) *nestedConfig {
+	if len(d.nestedConfigs) == 0 || filePath == "" {
+		return nil
+	}
+	rel := filePath
+	if r, err := filepath.Rel(d.nestedRoot, filePath); err == nil && !strings.HasPrefix(r, "..") {
+		rel = r
+	}
+	return d.nearestNestedConfig(path.Dir(filepath.ToSlash(rel)))
+}
+
+// nearestNestedConfig returns the nested config of dir, or of its closest
+// parent directory having one.
+func (d *Detector) nearestNestedConfig(dir string) *nestedConfig {
+	for dir != "." && dir != "/" && dir != "" {
+		if nc, ok := d.nestedConfigs[dir]; ok {
+			return nc
+		}
+		dir = path.Dir(dir)
+	}
+	return nil
+}
+
+// detector returns a copy of d scanning with the nested config.
+func (nc *nestedConfig) detector(d *Detector) *Detector {
+	e := *d
+	e.Config = nc.config
+	e.prefilter = nc.prefilter
+	e.nestedConfigs = nil
+	return &e
+}
@@ -0,0 +1,102 @@
+package detect
+
+import (
+	"context"
+	"fmt"
+)
+
+// Progress is the progress of a scan reported to OnProgress.
+type Progress struct {
+	// Commits is the number of commits seen by a git scan, including the
+	// commits whose files are still being scanned.
+	Commits int
+
+	// Files is the number of files scanned, or of files changed by a commit
+	// in git scans.
+	Files int
+
+	// Findings is the number of findings found so far, suppressed ones
+	// included.
+	Findings int
+
+	// LastCommit is the last commit of a git scan whose files, and the files
+	// of every commit output before it by git log, are all scanned. A scan of
+	// a single branch without merges stopped early resumes from its parent,
+	// ex: --log-opts="<LastCommit>^". Other scans list commits after it
+	// which are not its ancestors.
+	LastCommit string
+}
+
+// fileScanned records that a file was scanned and reports the progress of
+// the scan to OnProgress.
+func (d *Detector) fileScanned() {
+	d.findingMutex.Lock()
+	defer d.findingMutex.Unlock()
+	d.progress.Files++
+	if d.OnProgress != nil {
+		d.OnProgress(d.progress)
+	}
+}
+
+// commitFileScanned records that a file changed by commit was scanned,
+// completely or not, and reports the progress of the scan to OnProgress.
+func (d *Detector) commitFileScanned(commit string, complete bool) {
+	d.findingMutex.Lock()
+	defer d.findingMutex.Unlock()
+	d.progress.Files++
+	if complete && commit != "" {
+		d.pendingFiles[commit]--
+	}
+	d.advanceCommits(false)
+	if d.OnProgress != nil {
+		d.OnProgress(d.progress)
+	}
+}
+
+// advanceCommits moves progress.LastCommit past the commits whose files are
+// all scanned. Git may still output files of the last commit seen unless
+// listed is true, so it is never passed then. d.findingMutex must be held.
+func (d *Detector) advanceCommits(listed bool) {
+	end := len(d.commitOrder)
+	if !listed {
+		end--
+	}
+	for d.scannedCommits < end {
+		commit := d.commitOrder[d.scannedCommits]
+		if d.pendingFiles[commit] > 0 {
+			break
+		}
+		delete(d.pendingFiles, commit)
+		d.progress.LastCommit = commit
+		d.scannedCommits++
+	}
+}
+
+// PartialScanError is returned, along with the findings found so far, by the
+// Context variants of the Detect methods when their context is done before
+// the scan completes.
+type PartialScanError struct {
+	// Err is the error of the context, ex: context.DeadlineExceeded.
+	Err error
+
+	// Progress is the progress of the scan when it stopped.
+	Progress Progress
+}
+
+func (e *PartialScanError) Error() string {
+	return fmt.Sprintf("scan stopped after %d commit(s) and %d file(s): %s", e.Progress.Commits, e.Progress.Files, e.Err)
+}
+
+func (e *PartialScanError) Unwrap() error {
+	return e.Err
+}
+
+// partialScan returns a PartialScanError if ctx is done, or nil.
+func (d *Detector) partialScan(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	d.findingMutex.Lock()
+	defer d.findingMutex.Unlock()
+	return &PartialScanError{Err: ctx.Err(), Progress: d.progress}
+}
@@ -2,6 +2,7 @@ package detect
 
 import (
 	"bufio"
+	"context"
 	"io"
 
 	"github.com/zricethezav/gitleaks/v8/report"
@@ -9,11 +10,41 @@ import (
 
 // DetectReader accepts an io.Reader and a buffer size for the reader in KB
 func (d *Detector) DetectReader(r io.Reader, bufSize int) ([]report.Finding, error) {
+	return d.DetectReaderContext(context.Background(), r, bufSize)
+}
+
+// DetectReaderContext is DetectReader stopping once ctx is done, the findings
+// found so far are then returned with a PartialScanError. A read blocked on r
+// is not interrupted, ctx is checked between reads, or between the members of
+// an archive.
+func (d *Detector) DetectReaderContext(ctx context.Context, r io.Reader, bufSize int) ([]report.Finding, error) {
 	reader := bufio.NewReader(r)
 	buf := make([]byte, 0, 1000*bufSize)
 	findings := []report.Finding{}
+	add := func(finding report.Finding) {
+		d.verifyAndRedact(ctx, &finding)
+		if d.OnFinding != nil {
+			d.OnFinding(finding)
+		} else {
+			findings = append(findings, finding)
+		}
+		if d.Verbose {
+			printFinding(finding, d.NoColor)
+		}
+	}
+
+	if d.MaxArchiveDepth > 0 {
+		head, _ := reader.Peek(headerSize)
+		if format := archiveFormat(head); format != "" {
+			d.detectArchive(ctx, reader, format, "", 1, add)
+			return findings, d.partialScan(ctx)
+		}
+	}
 
 	for {
+		if err := d.partialScan(ctx); err != nil {
+			return findings, err
+		}
 		n, err := reader.Read(buf[:cap(buf)])
 		buf = buf[:n]
 		if err != nil {
@@ -26,11 +57,8 @@ func (d *Detector) DetectReader(r io.Reader, bufSize int) ([]report.Finding, err
 		fragment := Fragment{
 			Raw: string(buf),
 		}
-		for _, finding := range d.Detect(fragment) {
-			findings = append(findings, finding)
-			if d.Verbose {
-				printFinding(finding, d.NoColor)
-			}
+		for _, finding := range d.detect(fragment) {
+			add(finding)
 		}
 	}
 
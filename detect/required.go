@@ -0,0 +1,185 @@
+package detect
+
+import (
+	"strings"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+// auxiliaryMatches returns the closest match of every rule required by a
+// composite rule. ok is false if one of the required rules does not match
+// close enough to the match of the composite rule, in which case the match
+// should not be reported. A required rule referenced by ID only matches
+// where it would report a finding itself: its path, keywords, allowlists
+// and entropy threshold apply, and so do the global allowlists.
+func (d *Detector) auxiliaryMatches(fragment Fragment, raw string, segments []encodedSegment,
+	rule config.Rule, matchIndex []int, loc Location) (matches []report.AuxiliaryMatch, ok bool) {
+	span := originalSpan(matchIndex, segments)
+	for _, required := range rule.RequiredRules {
+		requiredRule := config.Rule{
+			Regex:       required.Regex,
+			SecretGroup: required.SecretGroup,
+		}
+		if required.RuleID != "" {
+			// the required rule may be left out by the rule selection
+			requiredRule, _ = d.Config.RequiredRule(required.RuleID)
+		}
+		if requiredRule.Regex == nil || !requiredRuleApplies(fragment, requiredRule) {
+			return nil, false
+		}
+		allowlists, ok := d.requiredAllowlists(fragment, requiredRule)
+		if !ok {
+			return nil, false
+		}
+
+		var (
+			closest  *report.AuxiliaryMatch
+			distance int
+		)
+		for _, m := range requiredRule.Regex.FindAllStringIndex(raw, -1) {
+			// the composite rule's own match cannot satisfy a requirement
+			if m[0] < matchIndex[1] && matchIndex[0] < m[1] {
+				continue
+			}
+			auxSpan := originalSpan(m, segments)
+			auxLoc := location(fragment, auxSpan)
+
+			gap := auxSpan[0] - span[1]
+			if auxSpan[0] < span[0] {
+				gap = span[0] - auxSpan[1]
+			}
+			if required.WithinBytes != nil && gap > *required.WithinBytes {
+				continue
+			}
+			if required.WithinLines != nil && abs(auxLoc.startLine-loc.startLine) > *required.WithinLines {
+				continue
+			}
+
+			match := strings.Trim(raw[m[0]:m[1]], "\n")
+			secret := match
+			groups := requiredRule.Regex.FindStringSubmatch(match)
+			if requiredRule.SecretGroup == 0 {
+				if len(groups) == 2 {
+					secret = groups[1]
+				}
+			} else if len(groups) > requiredRule.SecretGroup {
+				secret = groups[requiredRule.SecretGroup]
+			}
+			if secretAllowed(fragment, allowlists, secret) {
+				continue
+			}
+			if requiredRule.Entropy != 0 && shannonEntropy(secret) <= requiredRule.Entropy {
+				continue
+			}
+
+			if closest == nil || gap < distance {
+				closest = &report.AuxiliaryMatch{
+					RuleID:      required.RuleID,
+					StartLine:   auxLoc.startLine,
+					EndLine:     auxLoc.endLine,
+					StartColumn: auxLoc.startColumn,
+					EndColumn:   auxLoc.endColumn,
+					Match:       match,
+					Secret:      secret,
+				}
+				distance = gap
+			}
+		}
+		if closest == nil {
+			return nil, false
+		}
+		matches = append(matches, *closest)
+	}
+	return matches, true
+}
+
+// requiredRuleApplies returns true if the path and keywords of a required
+// rule allow it to match in fragment.
+func requiredRuleApplies(fragment Fragment, rule config.Rule) bool {
+	if rule.Path != nil && !rule.Path.MatchString(fragment.FilePath) {
+		return false
+	}
+	if len(rule.Keywords) == 0 {
+		return true
+	}
+	for _, k := range rule.Keywords {
+		if fragment.keywords[strings.ToLower(k)] {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredAllowlists returns the allowlists of a required rule and the global
+// allowlists which may suppress its matches in fragment. ok is false if one of
+// them suppresses all the matches of the fragment, e.g. by its path.
+func (d *Detector) requiredAllowlists(fragment Fragment, rule config.Rule) (allowlists []allowlist, ok bool) {
+	for _, a := range append(d.ruleAllowlists(rule), d.globalAllowlists()...) {
+		applies, stage, _ := a.fragmentMatch(fragment)
+		if stage != "" {
+			return nil, false
+		}
+		if applies {
+			allowlists = append(allowlists, a)
+		}
+	}
+	return allowlists, true
+}
+
+// removeAuxiliary removes the findings of required rules that are already
+// reported as an auxiliary match of a composite rule's finding.
+func (d *Detector) removeAuxiliary(findings []report.Finding) []report.Finding {
+	auxiliary := auxiliaryOf(findings)
+	if len(auxiliary) == 0 {
+		return findings
+	}
+
+	var retFindings []report.Finding
+	for _, f := range findings {
+		if composite, ok := auxiliary[auxiliaryKey(f)]; ok {
+			d.trace(d.Config.Rules[f.RuleID], StageRequired, &f, "reported as an auxiliary match of %s", composite)
+			continue
+		}
+		retFindings = append(retFindings, f)
+	}
+	return retFindings
+}
+
+// auxiliaryOf returns the auxiliary matches of required rules reported by the
+// findings of composite rules, mapped to the ID of the composite rule.
+// Suppressed findings do not report their auxiliary matches.
+func auxiliaryOf(findings []report.Finding) map[report.AuxiliaryMatch]string {
+	auxiliary := make(map[report.AuxiliaryMatch]string)
+	for _, f := range findings {
+		if f.Suppressed {
+			continue
+		}
+		for _, m := range f.AuxiliaryMatches {
+			if m.RuleID != "" {
+				auxiliary[m] = f.RuleID
+			}
+		}
+	}
+	return auxiliary
+}
+
+// auxiliaryKey returns the auxiliary match a finding would be reported as.
+func auxiliaryKey(f report.Finding) report.AuxiliaryMatch {
+	return report.AuxiliaryMatch{
+		RuleID:      f.RuleID,
+		StartLine:   f.StartLine,
+		EndLine:     f.EndLine,
+		StartColumn: f.StartColumn,
+		EndColumn:   f.EndColumn,
+		Match:       f.Match,
+		Secret:      f.Secret,
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
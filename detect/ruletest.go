@@ -0,0 +1,107 @@
+package detect
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+// RuleTestResult is the outcome of one true or false positive of a rule.
+type RuleTestResult struct {
+	RuleID string
+
+	// TruePositive is true if the rule must find a secret in Fragment and
+	// false if it must not find any.
+	TruePositive bool
+
+	// Index is the position of the case in the truePositives or
+	// falsePositives of the rule, or -1 for the cases keyed by Path.
+	Index int
+
+	// Path is the path the fragment is scanned with.
+	Path string
+
+	Fragment string
+
+	// Findings are the findings of the rule in Fragment.
+	Findings []report.Finding
+
+	Passed bool
+}
+
+// RunRuleTests checks the true and false positives of every rule of cfg which
+// declares some. A true positive passes if the rule finds at least one secret
+// in it and a false positive passes if the rule finds none. Each rule is run
+// on its own with the rules it requires and the global allowlists of cfg.
+func RunRuleTests(cfg config.Config) []RuleTestResult {
+	var results []RuleTestResult
+	for _, rule := range cfg.GetOrderedRules() {
+		d := NewDetector(ruleTestConfig(cfg, rule))
+		run := func(truePositive bool, index int, path, fragment string) {
+			var findings []report.Finding
+			for _, f := range d.Detect(Fragment{Raw: fragment, FilePath: path}) {
+				if f.RuleID == rule.RuleID {
+					findings = append(findings, f)
+				}
+			}
+			results = append(results, RuleTestResult{
+				RuleID:       rule.RuleID,
+				TruePositive: truePositive,
+				Index:        index,
+				Path:         path,
+				Fragment:     fragment,
+				Findings:     findings,
+				Passed:       truePositive == (len(findings) > 0),
+			})
+		}
+
+		for i, tp := range rule.TruePositives {
+			run(true, i, "", tp)
+		}
+		for _, path := range sortedKeys(rule.TruePositivePaths) {
+			run(true, -1, path, rule.TruePositivePaths[path])
+		}
+		for i, fp := range rule.FalsePositives {
+			run(false, i, "", fp)
+		}
+		for _, path := range sortedKeys(rule.FalsePositivePaths) {
+			run(false, -1, path, rule.FalsePositivePaths[path])
+		}
+	}
+	return results
+}
+
+// ruleTestConfig returns a config with rule, the rules it requires and the
+// global allowlists of cfg.
+func ruleTestConfig(cfg config.Config, rule config.Rule) config.Config {
+	rules := map[string]config.Rule{rule.RuleID: rule}
+	for _, req := range rule.RequiredRules {
+		if required, ok := cfg.RequiredRule(req.RuleID); ok {
+			rules[req.RuleID] = required
+		}
+	}
+	testCfg := config.Config{
+		Rules:      rules,
+		Allowlist:  cfg.Allowlist,
+		Allowlists: cfg.Allowlists,
+	}
+	for ruleID, r := range rules {
+		testCfg.OrderedRules = append(testCfg.OrderedRules, ruleID)
+		for _, k := range r.Keywords {
+			testCfg.Keywords = append(testCfg.Keywords, strings.ToLower(k))
+		}
+	}
+	sort.Strings(testCfg.OrderedRules)
+	return testCfg
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
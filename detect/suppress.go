@@ -0,0 +1,51 @@
+package detect
+
+import (
+	"fmt"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+// suppress handles a match dropped by stage. It returns true if the match
+// must be skipped or, if suppressed findings are included, marks finding as
+// suppressed and returns false so the remaining checks still apply to it.
+func (d *Detector) suppress(rule config.Rule, stage Stage, finding *report.Finding, format string, args ...interface{}) bool {
+	d.trace(rule, stage, finding, format, args...)
+	if !d.IncludeSuppressed {
+		return true
+	}
+	markSuppressed(finding, suppressionReason(stage, fmt.Sprintf(format, args...)))
+	return false
+}
+
+// suppressRule handles a rule dropped by stage for a whole fragment. It
+// returns true if the rule must be skipped or, if suppressed findings are
+// included, sets suppression to the reason the findings of the rule are
+// suppressed and returns false.
+func (d *Detector) suppressRule(rule config.Rule, stage Stage, suppression *string, format string, args ...interface{}) bool {
+	d.trace(rule, stage, nil, format, args...)
+	if !d.IncludeSuppressed {
+		return true
+	}
+	if *suppression == "" {
+		*suppression = suppressionReason(stage, fmt.Sprintf(format, args...))
+	}
+	return false
+}
+
+// suppressionReason returns the SuppressionReason of a finding dropped by
+// stage for reason.
+func suppressionReason(stage Stage, reason string) string {
+	return fmt.Sprintf("%s: %s", stage, reason)
+}
+
+// markSuppressed marks a finding as suppressed for reason, unless reason is
+// empty or the finding is already suppressed by an earlier stage.
+func markSuppressed(finding *report.Finding, reason string) {
+	if reason == "" || finding.Suppressed {
+		return
+	}
+	finding.Suppressed = true
+	finding.SuppressionReason = reason
+}
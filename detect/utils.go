@@ -19,8 +19,7 @@ import (
 // delta from the git diff
 func augmentGitFinding(finding report.Finding, textFragment *gitdiff.TextFragment, f *gitdiff.File) report.Finding {
 	if !strings.HasPrefix(finding.Match, "file detected") {
-		finding.StartLine += int(textFragment.NewPosition)
-		finding.EndLine += int(textFragment.NewPosition)
+		shiftLines(&finding, int(textFragment.NewPosition))
 	}
 
 	if f.PatchHeader != nil {
@@ -35,6 +34,17 @@ func augmentGitFinding(finding report.Finding, textFragment *gitdiff.TextFragmen
 	return finding
 }
 
+// shiftLines adds delta to the line numbers of a finding and of its
+// auxiliary matches
+func shiftLines(finding *report.Finding, delta int) {
+	finding.StartLine += delta
+	finding.EndLine += delta
+	for i := range finding.AuxiliaryMatches {
+		finding.AuxiliaryMatches[i].StartLine += delta
+		finding.AuxiliaryMatches[i].EndLine += delta
+	}
+}
+
 // shannonEntropy calculates the entropy of data using the formula defined here:
 // https://en.wiktionary.org/wiki/Shannon_entropy
 // Another way to think about what this is doing is calculating the number of bits
@@ -59,31 +69,19 @@ func shannonEntropy(data string) (entropy float64) {
 	return entropy
 }
 
-// filter will dedupe and redact findings
-func filter(findings []report.Finding, redact uint) []report.Finding {
+// filter drops the findings of generic rules superseded by a specific rule
+func (d *Detector) filter(findings []report.Finding) []report.Finding {
 	var retFindings []report.Finding
 	for _, f := range findings {
 		include := true
-		if strings.Contains(strings.ToLower(f.RuleID), "generic") {
-			for _, fPrime := range findings {
-				if f.StartLine == fPrime.StartLine &&
-					f.Commit == fPrime.Commit &&
-					f.RuleID != fPrime.RuleID &&
-					strings.Contains(fPrime.Secret, f.Secret) &&
-					!strings.Contains(strings.ToLower(fPrime.RuleID), "generic") {
-
-					genericMatch := strings.Replace(f.Match, f.Secret, "REDACTED", -1)
-					betterMatch := strings.Replace(fPrime.Match, fPrime.Secret, "REDACTED", -1)
-					log.Trace().Msgf("skipping %s finding (%s), %s rule takes precedence (%s)", f.RuleID, genericMatch, fPrime.RuleID, betterMatch)
-					include = false
-					break
-				}
-			}
+		if fPrime, ok := supersededBy(f, findings); ok {
+			genericMatch := strings.Replace(f.Match, f.Secret, "REDACTED", -1)
+			betterMatch := strings.Replace(fPrime.Match, fPrime.Secret, "REDACTED", -1)
+			log.Trace().Msgf("skipping %s finding (%s), %s rule takes precedence (%s)", f.RuleID, genericMatch, fPrime.RuleID, betterMatch)
+			d.trace(d.Config.Rules[f.RuleID], StageFilter, &f, "%s takes precedence on the same line", fPrime.RuleID)
+			include = false
 		}
 
-		if redact > 0 {
-			f.Redact(redact)
-		}
 		if include {
 			retFindings = append(retFindings, f)
 		}
@@ -91,6 +89,26 @@ func filter(findings []report.Finding, redact uint) []report.Finding {
 	return retFindings
 }
 
+// supersededBy returns the finding of a specific rule which takes precedence
+// over f, a finding of a generic rule on the same line whose secret it contains.
+// Suppressed findings only take precedence over suppressed findings.
+func supersededBy(f report.Finding, findings []report.Finding) (report.Finding, bool) {
+	if !strings.Contains(strings.ToLower(f.RuleID), "generic") {
+		return report.Finding{}, false
+	}
+	for _, fPrime := range findings {
+		if f.StartLine == fPrime.StartLine &&
+			f.Commit == fPrime.Commit &&
+			f.RuleID != fPrime.RuleID &&
+			(!fPrime.Suppressed || f.Suppressed) &&
+			strings.Contains(fPrime.Secret, f.Secret) &&
+			!strings.Contains(strings.ToLower(fPrime.RuleID), "generic") {
+			return fPrime, true
+		}
+	}
+	return report.Finding{}, false
+}
+
 func printFinding(f report.Finding, noColor bool) {
 	// trim all whitespace and tabs
 	f.Line = strings.TrimSpace(f.Line)
@@ -158,10 +176,29 @@ func printFinding(f report.Finding, noColor bool) {
 
 	fmt.Printf("%-12s %s\n", "RuleID:", f.RuleID)
 	fmt.Printf("%-12s %f\n", "Entropy:", f.Entropy)
+	if f.Verification != "" {
+		fmt.Printf("%-12s %s\n", "Status:", f.Verification)
+	}
+	if f.Suppressed {
+		fmt.Printf("%-12s %s\n", "Suppressed:", f.SuppressionReason)
+	}
+	if f.DecodeChain != "" {
+		fmt.Printf("%-12s %s\n", "Decoded:", f.DecodeChain)
+	}
+	for _, m := range f.AuxiliaryMatches {
+		required := m.RuleID
+		if required == "" {
+			required = "regex"
+		}
+		fmt.Printf("%-12s %s (%s, line %d)\n", "Requires:", strings.TrimSpace(m.Secret), required, m.StartLine)
+	}
 	if f.File == "" {
 		fmt.Println("")
 		return
 	}
+	if f.Layer != "" {
+		fmt.Printf("%-12s %s\n", "Layer:", f.Layer)
+	}
 	fmt.Printf("%-12s %s\n", "File:", f.File)
 	fmt.Printf("%-12s %d\n", "Line:", f.StartLine)
 	if f.Commit == "" {
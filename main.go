@@ -2,7 +2,6 @@ package main
 
 import (
 	"os"
-	"os/signal"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -13,16 +12,7 @@ func main() {
 	// send all logs to stdout
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 
-	// this block sets up a go routine to listen for an interrupt signal
-	// which will immediately exit gitleaks
-	stopChan := make(chan os.Signal, 1)
-	signal.Notify(stopChan, os.Interrupt)
-	go listenForInterrupt(stopChan)
-
+	// scans stop on an interrupt signal and report their findings so far,
+	// see scanContext in cmd, other commands exit at once
 	cmd.Execute()
 }
-
-func listenForInterrupt(stopScan chan os.Signal) {
-	<-stopScan
-	log.Fatal().Msg("Interrupt signal received. Exiting...")
-}
@@ -30,6 +30,10 @@ func writeCsv(f []Finding, w io.WriteCloser) error {
 		"Email",
 		"Fingerprint",
 		"Tags",
+		"Severity",
+		"Confidence",
+		"Verification",
+		"SuppressionReason",
 	})
 	if err != nil {
 		return err
@@ -51,6 +55,10 @@ func writeCsv(f []Finding, w io.WriteCloser) error {
 			f.Email,
 			f.Fingerprint,
 			strings.Join(f.Tags, " "),
+			f.Severity,
+			f.Confidence,
+			string(f.Verification),
+			f.SuppressionReason,
 		})
 		if err != nil {
 			return err
@@ -27,22 +27,84 @@ type Finding struct {
 	SymlinkFile string
 	Commit      string
 
+	// Layer is the digest of the container image layer containing the finding
+	Layer string `json:",omitempty"`
+
 	// Entropy is the shannon entropy of Value
 	Entropy float32
 
+	// StructurallyValid is set if the secret passed the structural
+	// checks, ex: an embedded checksum, of the rule's validator
+	StructurallyValid bool `json:",omitempty"`
+
+	// Verification is the result of checking whether the secret is
+	// active, it is only set when verification is enabled
+	Verification VerificationStatus `json:",omitempty"`
+
+	// Suppressed is set if an allowlist, a stop word, the entropy threshold,
+	// a gitleaks:allow comment, a .gitleaksignore file or the baseline would
+	// have dropped the finding. Suppressed findings are only reported with
+	// --include-suppressed.
+	Suppressed bool `json:",omitempty"`
+
+	// SuppressionReason names the stage and the entry which suppressed the
+	// finding, ex: "stop words: the secret contains rule stop word \"example\""
+	SuppressionReason string `json:",omitempty"`
+
+	// DecodeChain lists the encodings that were decoded to find the
+	// secret, outermost first, ex: "base64 -> url"
+	DecodeChain string `json:",omitempty"`
+
 	Author  string
 	Email   string
 	Date    string
 	Message string
 	Tags    []string
 
+	// Severity and Confidence are copied from the rule
+	Severity   string `json:",omitempty"`
+	Confidence string `json:",omitempty"`
+
 	// Rule is the name of the rule that was matched
 	RuleID string
 
+	// AuxiliaryMatches are the matches of the rules required by a
+	// composite rule
+	AuxiliaryMatches []AuxiliaryMatch `json:",omitempty"`
+
 	// unique identifier
 	Fingerprint string
 }
 
+// VerificationStatus is the result of verifying a secret with the service
+// that issued it.
+type VerificationStatus string
+
+const (
+	// Verified secrets are active.
+	Verified VerificationStatus = "Verified"
+
+	// Unverified secrets were rejected by the service.
+	Unverified VerificationStatus = "Unverified"
+
+	// VerificationError is set if the service could not be reached or
+	// did not give a conclusive response.
+	VerificationError VerificationStatus = "Error"
+)
+
+// AuxiliaryMatch is a match of a rule, or of an inline regex, required by
+// the rule of a finding.
+type AuxiliaryMatch struct {
+	// RuleID is empty if the match is of an inline regex
+	RuleID      string `json:",omitempty"`
+	StartLine   int
+	EndLine     int
+	StartColumn int
+	EndColumn   int
+	Match       string
+	Secret      string
+}
+
 // Redact removes sensitive information from a finding.
 func (f *Finding) Redact(percent uint) {
 	secret := maskSecret(f.Secret, percent)
@@ -52,6 +114,16 @@ func (f *Finding) Redact(percent uint) {
 	f.Line = strings.Replace(f.Line, f.Secret, secret, -1)
 	f.Match = strings.Replace(f.Match, f.Secret, secret, -1)
 	f.Secret = secret
+
+	for i, m := range f.AuxiliaryMatches {
+		auxSecret := maskSecret(m.Secret, percent)
+		if percent >= 100 {
+			auxSecret = "REDACTED"
+		}
+		f.Line = strings.Replace(f.Line, m.Secret, auxSecret, -1)
+		f.AuxiliaryMatches[i].Match = strings.Replace(m.Match, m.Secret, auxSecret, -1)
+		f.AuxiliaryMatches[i].Secret = auxSecret
+	}
 }
 
 func maskSecret(secret string, percent uint) string {
@@ -15,3 +15,25 @@ func writeJson(findings []Finding, w io.WriteCloser) error {
 	encoder.SetIndent("", " ")
 	return encoder.Encode(findings)
 }
+
+// partialJsonReport is the JSON report of a partial scan.
+type partialJsonReport struct {
+	IsPartial bool `json:"partial"`
+	Partial
+	Findings []Finding `json:"findings"`
+}
+
+func writePartialJson(findings []Finding, partial Partial, w io.WriteCloser) error {
+	if len(findings) == 0 {
+		findings = []Finding{}
+	}
+	defer w.Close()
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", " ")
+	return encoder.Encode(partialJsonReport{
+		IsPartial: true,
+		Partial:   partial,
+		Findings:  findings,
+	})
+}
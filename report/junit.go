@@ -8,10 +8,13 @@ import (
 	"strconv"
 )
 
-func writeJunit(findings []Finding, w io.WriteCloser) error {
+func writeJunit(findings []Finding, partial *Partial, w io.WriteCloser) error {
 	testSuites := TestSuites{
 		TestSuites: getTestSuites(findings),
 	}
+	if partial != nil {
+		testSuites.TestSuites[0].Properties = getPartialProperties(*partial)
+	}
 
 	io.WriteString(w, xml.Header)
 	encoder := xml.NewEncoder(w)
@@ -19,16 +22,41 @@ func writeJunit(findings []Finding, w io.WriteCloser) error {
 	return encoder.Encode(testSuites)
 }
 
+// getTestSuites reports the findings as failed test cases, and the
+// suppressed findings reported with --include-suppressed as skipped ones.
 func getTestSuites(findings []Finding) []TestSuite {
-	return []TestSuite{
-		{
-			Failures:  strconv.Itoa(len(findings)),
-			Name:      "gitleaks",
-			Tests:     strconv.Itoa(len(findings)),
-			TestCases: getTestCases(findings),
-			Time:      "",
-		},
+	var suppressed int
+	for _, f := range findings {
+		if f.Suppressed {
+			suppressed++
+		}
+	}
+	testSuite := TestSuite{
+		Failures:  strconv.Itoa(len(findings) - suppressed),
+		Name:      "gitleaks",
+		Tests:     strconv.Itoa(len(findings)),
+		TestCases: getTestCases(findings),
+		Time:      "",
+	}
+	if suppressed > 0 {
+		testSuite.Skipped = strconv.Itoa(suppressed)
+	}
+	return []TestSuite{testSuite}
+}
+
+// getPartialProperties returns the properties marking the test suite of a
+// partial scan.
+func getPartialProperties(partial Partial) *TestSuiteProperties {
+	properties := &TestSuiteProperties{
+		Properties: []Property{{Name: "partial", Value: "true"}},
+	}
+	if partial.Reason != "" {
+		properties.Properties = append(properties.Properties, Property{Name: "reason", Value: partial.Reason})
+	}
+	if partial.LastCommit != "" {
+		properties.Properties = append(properties.Properties, Property{Name: "lastCommit", Value: partial.LastCommit})
 	}
+	return properties
 }
 
 func getTestCases(findings []Finding) []TestCase {
@@ -36,11 +64,16 @@ func getTestCases(findings []Finding) []TestCase {
 	for _, f := range findings {
 		testCase := TestCase{
 			Classname: f.Description,
-			Failure:   getFailure(f),
 			File:      f.File,
 			Name:      getMessage(f),
 			Time:      "",
 		}
+		if f.Suppressed {
+			testCase.Skipped = &Skipped{Message: f.SuppressionReason}
+		} else {
+			failure := getFailure(f)
+			testCase.Failure = &failure
+		}
 		testCases = append(testCases, testCase)
 	}
 	return testCases
@@ -64,11 +97,16 @@ func getData(f Finding) string {
 }
 
 func getMessage(f Finding) string {
+	var message string
 	if f.Commit == "" {
-		return fmt.Sprintf("%s has detected a secret in file %s, line %s.", f.RuleID, f.File, strconv.Itoa(f.StartLine))
+		message = fmt.Sprintf("%s has detected a secret in file %s, line %s.", f.RuleID, f.File, strconv.Itoa(f.StartLine))
+	} else {
+		message = fmt.Sprintf("%s has detected a secret in file %s, line %s, at commit %s.", f.RuleID, f.File, strconv.Itoa(f.StartLine), f.Commit)
 	}
-
-	return fmt.Sprintf("%s has detected a secret in file %s, line %s, at commit %s.", f.RuleID, f.File, strconv.Itoa(f.StartLine), f.Commit)
+	if f.Severity != "" {
+		message = fmt.Sprintf("[%s] %s", f.Severity, message)
+	}
+	return message
 }
 
 type TestSuites struct {
@@ -77,23 +115,41 @@ type TestSuites struct {
 }
 
 type TestSuite struct {
-	XMLName   xml.Name   `xml:"testsuite"`
-	Failures  string     `xml:"failures,attr"`
-	Name      string     `xml:"name,attr"`
-	Tests     string     `xml:"tests,attr"`
-	TestCases []TestCase `xml:"testcase"`
-	Time      string     `xml:"time,attr"`
+	XMLName    xml.Name             `xml:"testsuite"`
+	Failures   string               `xml:"failures,attr"`
+	Name       string               `xml:"name,attr"`
+	Tests      string               `xml:"tests,attr"`
+	Skipped    string               `xml:"skipped,attr,omitempty"`
+	Properties *TestSuiteProperties `xml:"properties,omitempty"`
+	TestCases  []TestCase           `xml:"testcase"`
+	Time       string               `xml:"time,attr"`
+}
+
+type TestSuiteProperties struct {
+	XMLName    xml.Name   `xml:"properties"`
+	Properties []Property `xml:"property"`
+}
+
+type Property struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
 }
 
 type TestCase struct {
 	XMLName   xml.Name `xml:"testcase"`
 	Classname string   `xml:"classname,attr"`
-	Failure   Failure  `xml:"failure"`
+	Failure   *Failure `xml:"failure"`
+	Skipped   *Skipped `xml:"skipped"`
 	File      string   `xml:"file,attr"`
 	Name      string   `xml:"name,attr"`
 	Time      string   `xml:"time,attr"`
 }
 
+type Skipped struct {
+	XMLName xml.Name `xml:"skipped"`
+	Message string   `xml:"message,attr"`
+}
+
 type Failure struct {
 	XMLName xml.Name `xml:"failure"`
 	Data    string   `xml:",chardata"`
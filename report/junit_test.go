@@ -58,6 +58,38 @@ func TestWriteJunit(t *testing.T) {
 				},
 			},
 		},
+		{
+			testReportName: "suppressed",
+			expected:       filepath.Join(expectPath, "report", "junit_suppressed.xml"),
+			findings: []Finding{
+				{
+					Description: "Test Rule",
+					RuleID:      "test-rule",
+					Match:       "line containing secret",
+					Secret:      "a secret",
+					StartLine:   1,
+					EndLine:     1,
+					StartColumn: 1,
+					EndColumn:   2,
+					File:        "auth.py",
+					Tags:        []string{},
+				},
+				{
+					Description:       "Test Rule",
+					RuleID:            "test-rule",
+					Match:             "line containing secret",
+					Secret:            "a secret",
+					StartLine:         2,
+					EndLine:           2,
+					StartColumn:       1,
+					EndColumn:         2,
+					File:              "test/auth.py",
+					Tags:              []string{},
+					Suppressed:        true,
+					SuppressionReason: "path test/auth.py matches global allowlist path ^test/",
+				},
+			},
+		},
 		{
 			testReportName: "empty",
 			expected:       filepath.Join(expectPath, "report", "junit_empty.xml"),
@@ -68,7 +100,7 @@ func TestWriteJunit(t *testing.T) {
 	for _, test := range tests {
 		tmpfile, err := os.Create(filepath.Join(t.TempDir(), test.testReportName+".xml"))
 		require.NoError(t, err)
-		err = writeJunit(test.findings, tmpfile)
+		err = writeJunit(test.findings, nil, tmpfile)
 		require.NoError(t, err)
 		assert.FileExists(t, tmpfile.Name())
 		got, err := os.ReadFile(tmpfile.Name())
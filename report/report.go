@@ -13,7 +13,26 @@ const (
 	CWE_DESCRIPTION = "Use of Hard-coded Credentials"
 )
 
+// Partial describes a scan stopped before it completed, see WritePartial.
+type Partial struct {
+	// Reason is why the scan stopped, ex: "interrupted".
+	Reason string `json:"reason,omitempty"`
+
+	// LastCommit is the last commit of a git scan whose changes, and the
+	// changes of the commits git log listed before it, were all scanned.
+	LastCommit string `json:"lastCommit,omitempty"`
+}
+
 func Write(findings []Finding, cfg config.Config, ext string, reportPath string) error {
+	return WritePartial(findings, cfg, ext, reportPath, nil)
+}
+
+// WritePartial is Write marking the report as the report of a partial scan
+// if partial is not nil. JSON reports are then an object with "partial": true,
+// the details of partial and the findings, SARIF runs have an invocation with
+// executionSuccessful set to false and JUnit test suites have a partial
+// property. CSV reports are not marked.
+func WritePartial(findings []Finding, cfg config.Config, ext string, reportPath string, partial *Partial) error {
 	file, err := os.Create(reportPath)
 	if err != nil {
 		return err
@@ -21,13 +40,17 @@ func Write(findings []Finding, cfg config.Config, ext string, reportPath string)
 	ext = strings.ToLower(ext)
 	switch ext {
 	case ".json", "json":
-		err = writeJson(findings, file)
+		if partial != nil {
+			err = writePartialJson(findings, *partial, file)
+		} else {
+			err = writeJson(findings, file)
+		}
 	case ".csv", "csv":
 		err = writeCsv(findings, file)
 	case ".xml", "junit":
-		err = writeJunit(findings, file)
+		err = writeJunit(findings, partial, file)
 	case ".sarif", "sarif":
-		err = writeSarif(cfg, findings, file)
+		err = writeSarif(cfg, findings, partial, file)
 	}
 
 	return err
@@ -114,3 +114,37 @@ func TestReport(t *testing.T) {
 		})
 	}
 }
+
+func TestWritePartial(t *testing.T) {
+	findings := []Finding{{RuleID: "test-rule", Commit: "0f8b3e7"}}
+	partial := &Partial{Reason: "interrupted", LastCommit: "0f8b3e7"}
+	tests := []struct {
+		ext    string
+		expect []string
+	}{
+		{
+			ext:    "json",
+			expect: []string{`"partial": true`, `"reason": "interrupted"`, `"lastCommit": "0f8b3e7"`, `"findings": [`},
+		},
+		{
+			ext:    "sarif",
+			expect: []string{`"executionSuccessful": false`, `"partial": true`, `"lastCommit": "0f8b3e7"`},
+		},
+		{
+			ext:    "junit",
+			expect: []string{`<property name="partial" value="true">`, `<property name="lastCommit" value="0f8b3e7">`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ext, func(t *testing.T) {
+			reportPath := filepath.Join(t.TempDir(), "report."+test.ext)
+			require.NoError(t, WritePartial(findings, config.Config{}, test.ext, reportPath, partial))
+			got, err := os.ReadFile(reportPath)
+			require.NoError(t, err)
+			for _, expect := range test.expect {
+				assert.Contains(t, string(got), expect)
+			}
+		})
+	}
+}
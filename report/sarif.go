@@ -4,16 +4,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/zricethezav/gitleaks/v8/config"
 )
 
-func writeSarif(cfg config.Config, findings []Finding, w io.WriteCloser) error {
+func writeSarif(cfg config.Config, findings []Finding, partial *Partial, w io.WriteCloser) error {
 	sarif := Sarif{
 		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
 		Version: "2.1.0",
 		Runs:    getRuns(cfg, findings),
 	}
+	if partial != nil {
+		sarif.Runs[0].Invocations = getPartialInvocations(*partial)
+	}
 	defer w.Close()
 
 	encoder := json.NewEncoder(w)
@@ -30,6 +34,25 @@ func getRuns(cfg config.Config, findings []Finding) []Runs {
 	}
 }
 
+// getPartialInvocations returns the invocation of a partial scan, which did
+// not complete.
+func getPartialInvocations(partial Partial) []Invocation {
+	invocation := Invocation{
+		ExecutionSuccessful: false,
+		Properties: &InvocationProperties{
+			Partial:    true,
+			LastCommit: partial.LastCommit,
+		},
+	}
+	if partial.Reason != "" {
+		invocation.ToolExecutionNotifications = []Notification{{
+			Level:   "error",
+			Message: Message{Text: "the scan did not complete: " + partial.Reason},
+		}}
+	}
+	return []Invocation{invocation}
+}
+
 func getTool(cfg config.Config) Tool {
 	tool := Tool{
 		Driver: Driver{
@@ -94,6 +117,7 @@ func getResults(findings []Finding) []Results {
 				Text: messageText(f),
 			},
 			RuleId:    f.RuleID,
+			Level:     sarifLevel(f.Severity),
 			Locations: getLocation(f),
 			// This information goes in partial fingerprings until revision
 			// data can be added somewhere else
@@ -105,14 +129,52 @@ func getResults(findings []Finding) []Results {
 				Author:        f.Author,
 			},
 			Properties: Properties{
-				Tags: f.Tags,
+				Tags:         f.Tags,
+				Severity:     f.Severity,
+				Confidence:   f.Confidence,
+				Verification: f.Verification,
 			},
+			Suppressions: getSuppressions(f),
 		}
 		results = append(results, r)
 	}
 	return results
 }
 
+// getSuppressions maps the suppression of a finding to a SARIF suppression:
+// gitleaks:allow comments suppress a finding in its source, the allowlists,
+// .gitleaksignore files and baselines outside of it.
+func getSuppressions(f Finding) []Suppression {
+	if !f.Suppressed {
+		return nil
+	}
+	kind := "external"
+	if strings.HasPrefix(f.SuppressionReason, "gitleaks:allow:") {
+		kind = "inSource"
+	}
+	return []Suppression{
+		{
+			Kind:          kind,
+			Justification: f.SuppressionReason,
+		},
+	}
+}
+
+// sarifLevel maps the severity of a finding to a SARIF result level. The
+// level is omitted for findings without a severity, which SARIF treats as
+// "warning".
+func sarifLevel(severity string) string {
+	switch severity {
+	case config.SeverityCritical, config.SeverityHigh:
+		return "error"
+	case config.SeverityMedium:
+		return "warning"
+	case config.SeverityLow, config.SeverityInfo:
+		return "note"
+	}
+	return ""
+}
+
 func getLocation(f Finding) []Locations {
 	uri := f.File
 	if f.SymlinkFile != "" {
@@ -207,18 +269,45 @@ type Locations struct {
 }
 
 type Properties struct {
-	Tags []string `json:"tags"`
+	Tags         []string           `json:"tags"`
+	Severity     string             `json:"severity,omitempty"`
+	Confidence   string             `json:"confidence,omitempty"`
+	Verification VerificationStatus `json:"verification,omitempty"`
 }
 
 type Results struct {
 	Message             Message     `json:"message"`
 	RuleId              string      `json:"ruleId"`
+	Level               string      `json:"level,omitempty"`
 	Locations           []Locations `json:"locations"`
 	PartialFingerPrints `json:"partialFingerprints"`
-	Properties          Properties `json:"properties"`
+	Properties          Properties    `json:"properties"`
+	Suppressions        []Suppression `json:"suppressions,omitempty"`
+}
+
+type Suppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
 }
 
 type Runs struct {
-	Tool    Tool      `json:"tool"`
-	Results []Results `json:"results"`
+	Tool        Tool         `json:"tool"`
+	Invocations []Invocation `json:"invocations,omitempty"`
+	Results     []Results    `json:"results"`
+}
+
+type Invocation struct {
+	ExecutionSuccessful        bool                  `json:"executionSuccessful"`
+	ToolExecutionNotifications []Notification        `json:"toolExecutionNotifications,omitempty"`
+	Properties                 *InvocationProperties `json:"properties,omitempty"`
+}
+
+type Notification struct {
+	Level   string  `json:"level"`
+	Message Message `json:"message"`
+}
+
+type InvocationProperties struct {
+	Partial    bool   `json:"partial"`
+	LastCommit string `json:"lastCommit,omitempty"`
 }
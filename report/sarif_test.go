@@ -46,10 +46,42 @@ func TestWriteSarif(t *testing.T) {
 					Tags:        []string{"tag1", "tag2", "tag3"},
 				},
 			}},
+		{
+			cfgName:        "simple",
+			testReportName: "suppressed",
+			expected:       filepath.Join(expectPath, "report", "sarif_suppressed.sarif"),
+			findings: []Finding{
+				{
+					Description:       "A test rule",
+					RuleID:            "test-rule",
+					Match:             "secret = 'a secret' # gitleaks:allow",
+					Secret:            "a secret",
+					StartLine:         1,
+					EndLine:           1,
+					StartColumn:       10,
+					EndColumn:         19,
+					File:              "auth.py",
+					Suppressed:        true,
+					SuppressionReason: "gitleaks:allow: the line contains gitleaks:allow",
+				},
+				{
+					Description:       "A test rule",
+					RuleID:            "test-rule",
+					Match:             "EXAMPLE_SECRET",
+					Secret:            "EXAMPLE_SECRET",
+					StartLine:         3,
+					EndLine:           3,
+					StartColumn:       1,
+					EndColumn:         14,
+					File:              "auth.py",
+					Suppressed:        true,
+					SuppressionReason: `stop words: the secret contains rule stop word "example"`,
+				},
+			}},
 	}
 
 	for _, test := range tests {
-		t.Run(test.cfgName, func(t *testing.T) {
+		t.Run(test.testReportName, func(t *testing.T) {
 			tmpfile, err := os.Create(filepath.Join(t.TempDir(), test.testReportName+".json"))
 			require.NoError(t, err)
 			viper.Reset()
@@ -65,7 +97,7 @@ func TestWriteSarif(t *testing.T) {
 
 			cfg, err := vc.Translate()
 			require.NoError(t, err)
-			err = writeSarif(cfg, test.findings, tmpfile)
+			err = writeSarif(cfg, test.findings, nil, tmpfile)
 			require.NoError(t, err)
 			assert.FileExists(t, tmpfile.Name())
 			got, err := os.ReadFile(tmpfile.Name())
@@ -1,6 +1,7 @@
 package sources
 
 import (
+	"context"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -15,7 +16,21 @@ type ScanTarget struct {
 }
 
 func DirectoryTargets(source string, s *semgroup.Group, followSymlinks bool) (<-chan ScanTarget, error) {
+	return DirectoryTargetsContext(context.Background(), source, s, followSymlinks)
+}
+
+// DirectoryTargetsContext is DirectoryTargets stopping the walk of source
+// once ctx is done.
+func DirectoryTargetsContext(ctx context.Context, source string, s *semgroup.Group, followSymlinks bool) (<-chan ScanTarget, error) {
 	paths := make(chan ScanTarget)
+	send := func(target ScanTarget) error {
+		select {
+		case paths <- target:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 	s.Go(func() error {
 		defer close(paths)
 		return filepath.Walk(source,
@@ -23,6 +38,9 @@ func DirectoryTargets(source string, s *semgroup.Group, followSymlinks bool) (<-
 				if err != nil {
 					return err
 				}
+				if err := ctx.Err(); err != nil {
+					return err
+				}
 				if fInfo.Name() == ".git" && fInfo.IsDir() {
 					return filepath.SkipDir
 				}
@@ -30,9 +48,8 @@ func DirectoryTargets(source string, s *semgroup.Group, followSymlinks bool) (<-
 					return nil
 				}
 				if fInfo.Mode().IsRegular() {
-					paths <- ScanTarget{
-						Path:    path,
-						Symlink: "",
+					if err := send(ScanTarget{Path: path}); err != nil {
+						return err
 					}
 				}
 				if fInfo.Mode().Type() == fs.ModeSymlink && followSymlinks {
@@ -45,10 +62,10 @@ func DirectoryTargets(source string, s *semgroup.Group, followSymlinks bool) (<-
 						log.Debug().Msgf("found symlinked directory: %s -> %s [skipping]", path, realPath)
 						return nil
 					}
-					paths <- ScanTarget{
+					return send(ScanTarget{
 						Path:    realPath,
 						Symlink: path,
-					}
+					})
 				}
 				return nil
 			})
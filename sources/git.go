@@ -50,7 +50,31 @@ func NewGitLogCmd(source string, logOpts string) (*GitCmd, error) {
 		cmd = exec.Command("git", "-C", sourceClean, "log", "-p", "-U0",
 			"--full-history", "--all")
 	}
+	return startGitLogCmd(cmd)
+}
+
+// newGitLogCmdRevs is NewGitLogCmd listing the commits reachable from
+// include but not from exclude. The commits are passed on the standard input
+// of git log, which is not limited in size like its arguments.
+func newGitLogCmdRevs(source string, include []string, exclude []string) (*GitCmd, error) {
+	var revs strings.Builder
+	for _, commit := range include {
+		revs.WriteString(commit + "\n")
+	}
+	// git log only reads options such as --not on its standard input since
+	// git 2.42
+	for _, commit := range exclude {
+		revs.WriteString("^" + commit + "\n")
+	}
+	cmd := exec.Command("git", "-C", filepath.Clean(source), "log", "-p", "-U0",
+		"--full-history", "--stdin")
+	cmd.Stdin = strings.NewReader(revs.String())
+	log.Debug().Msgf("passing %d commits to list and %d commits to exclude on the standard input of git log", len(include), len(exclude))
+	return startGitLogCmd(cmd)
+}
 
+// startGitLogCmd starts cmd, a git log command, and parses its output.
+func startGitLogCmd(cmd *exec.Cmd) (*GitCmd, error) {
 	log.Debug().Msgf("executing: %s", cmd.String())
 
 	stdout, err := cmd.StdoutPipe()
@@ -138,6 +162,15 @@ func (c *GitCmd) Wait() (err error) {
 	return c.cmd.Wait()
 }
 
+// Kill kills the git process. Its output is cut short and the channels are
+// closed once they are drained.
+func (c *GitCmd) Kill() error {
+	if c.cmd.Process == nil {
+		return nil
+	}
+	return c.cmd.Process.Kill()
+}
+
 // listenForStdErr listens for stderr output from git, prints it to stdout,
 // sends to errCh and closes it.
 func listenForStdErr(stderr io.ReadCloser, errCh chan<- error) {
@@ -0,0 +1,354 @@
+package sources
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	mediaTypeOCIIndex    = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerIndex = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// dockerSaveManifest is written by `docker save`, ociLayoutIndex is the
+	// entrypoint of an OCI image layout. Newer versions of docker write both.
+	dockerSaveManifest = "manifest.json"
+	ociLayoutIndex     = "index.json"
+
+	maxImageIndexDepth = 4
+)
+
+// Image is a container image read from a `docker save` tarball or an OCI
+// image layout directory. Caller should call `func (*Image) Close()` in order
+// to release resources.
+type Image struct {
+	// Configs are the configs of the images of the tarball or layout, a
+	// `docker save` tarball may hold several images
+	Configs []ImageConfig
+
+	// Layers are the layers of the image, ordered from the base layer up
+	Layers []ImageLayer
+
+	fs imageFS
+}
+
+// ImageConfig is the config of one of the images of an Image.
+type ImageConfig struct {
+	// Digest is the digest (or path) of the image config
+	Digest string
+
+	// Env contains the environment variables set in the image config
+	Env []string
+
+	// History contains the `created_by` entries of the image history
+	History []string
+}
+
+// ImageLayer is a single layer of an Image.
+type ImageLayer struct {
+	// Digest is the digest of the layer if it is known, otherwise it is the
+	// path of the layer inside the image tarball or layout.
+	Digest string
+
+	name string
+	fs   imageFS
+}
+
+// imageFS opens files from a `docker save` tarball or an image layout directory.
+type imageFS interface {
+	Open(name string) (io.ReadCloser, error)
+	Close() error
+}
+
+// NewImage reads the manifests and configs of the image tarball or OCI image
+// layout directory located at source.
+func NewImage(source string) (*Image, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var fsys imageFS
+	if info.IsDir() {
+		fsys = dirImageFS(source)
+	} else {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		fsys = &tarImageFS{file: f}
+	}
+
+	img := &Image{fs: fsys}
+	if err := img.load(); err != nil {
+		fsys.Close()
+		return nil, fmt.Errorf("unable to read image %s: %w", source, err)
+	}
+	return img, nil
+}
+
+// Close releases the resources held by the image.
+func (img *Image) Close() error {
+	return img.fs.Close()
+}
+
+// Open returns the uncompressed tar stream of the layer. Only one layer
+// of an image may be open at a time.
+func (l ImageLayer) Open() (io.ReadCloser, error) {
+	rc, err := l.fs.Open(l.name)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(rc)
+	magic, _ := br.Peek(2)
+	if bytes.Equal(magic, []byte{0x28, 0xb5}) {
+		rc.Close()
+		return nil, fmt.Errorf("layer %s is zstd compressed which is not supported", l.Digest)
+	}
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			rc.Close()
+			return nil, err
+		}
+		return &layerReader{Reader: gz, closers: []io.Closer{gz, rc}}, nil
+	}
+	return &layerReader{Reader: br, closers: []io.Closer{rc}}, nil
+}
+
+type layerReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *layerReader) Close() error {
+	var err error
+	for _, c := range r.closers {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+type imageConfig struct {
+	Config struct {
+		Env []string
+	} `json:"config"`
+	History []struct {
+		CreatedBy string `json:"created_by"`
+	} `json:"history"`
+	RootFS struct {
+		DiffIDs []string `json:"diff_ids"`
+	} `json:"rootfs"`
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+type ociManifest struct {
+	MediaType string       `json:"mediaType"`
+	Config    descriptor   `json:"config"`
+	Layers    []descriptor `json:"layers"`
+	Manifests []descriptor `json:"manifests"`
+}
+
+type dockerSaveEntry struct {
+	Config string
+	Layers []string
+}
+
+// load populates the image from a `docker save` manifest.json if it exists
+// and from an OCI index.json otherwise.
+func (img *Image) load() error {
+	var entries []dockerSaveEntry
+	err := img.readJSON(dockerSaveManifest, &entries)
+	if err == nil {
+		for _, e := range entries {
+			if err := img.addDockerSaveEntry(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	var index ociManifest
+	if err := img.readJSON(ociLayoutIndex, &index); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("neither manifest.json nor index.json found")
+		}
+		return err
+	}
+	return img.addOCIIndex(index, 0)
+}
+
+func (img *Image) addDockerSaveEntry(e dockerSaveEntry) error {
+	var cfg imageConfig
+	if err := img.readJSON(e.Config, &cfg); err != nil {
+		return err
+	}
+	img.addConfig(configDigest(e.Config), cfg)
+	for i, name := range e.Layers {
+		digest := blobDigest(name)
+		if i < len(cfg.RootFS.DiffIDs) {
+			digest = cfg.RootFS.DiffIDs[i]
+		}
+		img.addLayer(digest, name)
+	}
+	return nil
+}
+
+func (img *Image) addOCIIndex(index ociManifest, depth int) error {
+	if depth > maxImageIndexDepth {
+		return errors.New("image index is nested too deeply")
+	}
+	for _, desc := range index.Manifests {
+		var m ociManifest
+		if err := img.readJSON(blobPath(desc.Digest), &m); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// multi-platform images often only contain the blobs of
+				// the platform that was pulled
+				log.Debug().Msgf("skipping manifest %s, blob not found", desc.Digest)
+				continue
+			}
+			return err
+		}
+		if desc.MediaType == mediaTypeOCIIndex || desc.MediaType == mediaTypeDockerIndex ||
+			len(m.Manifests) > 0 {
+			if err := img.addOCIIndex(m, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var cfg imageConfig
+		if err := img.readJSON(blobPath(m.Config.Digest), &cfg); err != nil {
+			return err
+		}
+		img.addConfig(m.Config.Digest, cfg)
+		for _, layer := range m.Layers {
+			img.addLayer(layer.Digest, blobPath(layer.Digest))
+		}
+	}
+	return nil
+}
+
+func (img *Image) addConfig(digest string, cfg imageConfig) {
+	// the manifests of several tags may reference the same config
+	for _, c := range img.Configs {
+		if c.Digest == digest {
+			return
+		}
+	}
+	c := ImageConfig{Digest: digest, Env: cfg.Config.Env}
+	for _, h := range cfg.History {
+		if h.CreatedBy != "" {
+			c.History = append(c.History, h.CreatedBy)
+		}
+	}
+	img.Configs = append(img.Configs, c)
+}
+
+func (img *Image) addLayer(digest string, name string) {
+	// images sharing a base will reference the same layers
+	for _, l := range img.Layers {
+		if l.name == name {
+			return
+		}
+	}
+	img.Layers = append(img.Layers, ImageLayer{Digest: digest, name: name, fs: img.fs})
+}
+
+func (img *Image) readJSON(name string, v interface{}) error {
+	rc, err := img.fs.Open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := json.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("unable to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// blobPath returns the path of a blob in an OCI image layout.
+func blobPath(digest string) string {
+	return path.Join("blobs", strings.Replace(digest, ":", "/", 1))
+}
+
+// blobDigest returns the digest of the blob at name if name is the path of a
+// blob, otherwise name is returned.
+func blobDigest(name string) string {
+	parts := strings.Split(path.Clean(name), "/")
+	if len(parts) == 3 && parts[0] == "blobs" {
+		return parts[1] + ":" + parts[2]
+	}
+	return name
+}
+
+// configDigest returns the digest of a `docker save` config, which is named
+// after its sha256 digest in older versions of docker.
+func configDigest(name string) string {
+	if digest := blobDigest(name); digest != name {
+		return digest
+	}
+	return "sha256:" + strings.TrimSuffix(path.Base(name), ".json")
+}
+
+// dirImageFS opens files from an image layout directory.
+type dirImageFS string
+
+func (d dirImageFS) Open(name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(string(d), filepath.FromSlash(name)))
+}
+
+func (d dirImageFS) Close() error {
+	return nil
+}
+
+// tarImageFS opens files from an image tarball. The tarball is searched
+// from the beginning every time a file is opened so only one file can be
+// open at a time.
+type tarImageFS struct {
+	file *os.File
+}
+
+func (t *tarImageFS) Open(name string) (io.ReadCloser, error) {
+	if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	name = path.Clean(name)
+	tr := tar.NewReader(t.file)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s: %w", name, os.ErrNotExist)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if path.Clean(header.Name) == name {
+			return io.NopCloser(tr), nil
+		}
+	}
+}
+
+func (t *tarImageFS) Close() error {
+	return t.file.Close()
+}
@@ -0,0 +1,210 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GitState is the state of an incremental git scan: the commits the refs of
+// the repository pointed to when it was last scanned completely.
+type GitState struct {
+	// Refs maps the names of the refs, and HEAD, to their commit.
+	Refs map[string]string `json:"refs"`
+
+	// Ancestors are first-parent ancestors of the commits of Refs, 1, 2,
+	// 4... commits behind them. They still exclude most of the commits
+	// scanned once a force push replaced the commits of Refs and they were
+	// pruned.
+	Ancestors []string `json:"ancestors,omitempty"`
+}
+
+// maxAncestorDistance bounds the distance of the ancestors of a GitState.
+const maxAncestorDistance = 1 << 10
+
+// LoadGitState reads the state saved at path. A missing file is an empty
+// state, the first incremental scan scans every commit.
+func LoadGitState(path string) (GitState, error) {
+	var state GitState
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("the format of the state file %s is not supported: %w", path, err)
+	}
+	return state, nil
+}
+
+// Save writes the state to path, replacing the previous state only once it
+// is written completely.
+func (s GitState) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", " ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// NewGitLogCmdSince is NewGitLogCmd listing the commits reachable from the
+// refs of the repository but not from the commits of scanned, the state of
+// the last complete scan. The commits of refs deleted or force pushed since
+// are excluded while they are in the repository, and by their ancestors in
+// scanned once they are pruned. It also returns the state to save once the
+// scan is complete.
+func NewGitLogCmdSince(source string, scanned GitState) (*GitCmd, GitState, error) {
+	refs, err := GitRefs(source)
+	if err != nil {
+		return nil, GitState{}, err
+	}
+	state := GitState{Refs: refs}
+
+	var tips []string
+	for _, commit := range refs {
+		tips = append(tips, commit)
+	}
+	tips = uniqueSorted(tips)
+	var ancestors []string
+	for _, tip := range tips {
+		for distance := 1; distance <= maxAncestorDistance; distance *= 2 {
+			ancestors = append(ancestors, fmt.Sprintf("%s~%d", tip, distance))
+		}
+	}
+	if state.Ancestors, err = resolveCommits(source, ancestors); err != nil {
+		return nil, GitState{}, err
+	}
+
+	// the commits reachable from the scanned ones, still in the repository,
+	// were scanned
+	oldCommits := append([]string{}, scanned.Ancestors...)
+	for _, commit := range scanned.Refs {
+		oldCommits = append(oldCommits, commit)
+	}
+	excluded, err := resolveCommits(source, oldCommits)
+	if err != nil {
+		return nil, GitState{}, err
+	}
+	existing := make(map[string]bool, len(excluded))
+	for _, commit := range excluded {
+		existing[commit] = true
+	}
+	for ref, commit := range scanned.Refs {
+		if !existing[commit] {
+			log.Debug().Msgf("%s pointed to %s which is no longer in the repository, it is replaced by its ancestors", ref, commit)
+		}
+	}
+
+	var gitCmd *GitCmd
+	if len(tips) > 0 {
+		gitCmd, err = newGitLogCmdRevs(source, tips, excluded)
+	} else {
+		// an empty repository has no tips, and --all then lists nothing
+		gitCmd, err = NewGitLogCmd(source, "")
+	}
+	if err != nil {
+		return nil, GitState{}, err
+	}
+	return gitCmd, state, nil
+}
+
+// GitRefs returns the commits the refs of the repository at source, and
+// HEAD, point to by name. Annotated tags are resolved to their commit and
+// refs to other objects are left out.
+func GitRefs(source string) (map[string]string, error) {
+	sourceClean := filepath.Clean(source)
+	out, err := exec.Command("git", "-C", sourceClean, "for-each-ref",
+		"--format=%(refname) %(objecttype) %(objectname) %(*objecttype) %(*objectname)").Output()
+	if err != nil {
+		return nil, gitError("could not list the refs of "+sourceClean, err)
+	}
+
+	refs := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) >= 3 && fields[1] == "commit":
+			refs[fields[0]] = fields[2]
+		case len(fields) == 5 && fields[3] == "commit":
+			refs[fields[0]] = fields[4]
+		}
+	}
+
+	// HEAD is only missing from the refs when it is detached
+	if out, err := exec.Command("git", "-C", sourceClean, "rev-parse", "--verify", "--quiet", "HEAD^{commit}").Output(); err == nil {
+		refs["HEAD"] = strings.TrimSpace(string(out))
+	}
+	return refs, nil
+}
+
+// resolveCommits returns the commits revs resolve to in the repository at
+// source, sorted and without duplicates, leaving out the missing ones.
+func resolveCommits(source string, revs []string) ([]string, error) {
+	if len(revs) == 0 {
+		return nil, nil
+	}
+	var input strings.Builder
+	for _, rev := range revs {
+		input.WriteString(rev + "^{commit}\n")
+	}
+	cmd := exec.Command("git", "-C", filepath.Clean(source), "cat-file", "--batch-check=%(objectname)")
+	cmd.Stdin = strings.NewReader(input.String())
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, gitError("could not resolve the commits of the state", err)
+	}
+	var commits []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		// missing objects are reported as "<rev>^{commit} missing"
+		if line := scanner.Text(); !strings.HasSuffix(line, " missing") {
+			commits = append(commits, line)
+		}
+	}
+	return uniqueSorted(commits), nil
+}
+
+// gitError adds the stderr output of a failed git command to err.
+func gitError(msg string, err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%s: %s", msg, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
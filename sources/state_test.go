@@ -0,0 +1,141 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitLogCmdSince(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo,
+			"-c", "user.name=gitleaks", "-c", "user.email=gitleaks@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	commit := func(name string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repo, name), []byte(name+"\n"), 0o600))
+		git("add", name)
+		git("commit", "-q", "-m", name)
+	}
+	scan := func(state GitState) ([]string, GitState) {
+		gitCmd, next, err := NewGitLogCmdSince(repo, state)
+		require.NoError(t, err)
+		var files []string
+		for f := range gitCmd.DiffFilesCh() {
+			files = append(files, f.NewName)
+		}
+		for range gitCmd.ErrCh() {
+		}
+		require.NoError(t, gitCmd.Wait())
+		return files, next
+	}
+
+	git("init", "-q", "-b", "main")
+	for i := 0; i < 5; i++ {
+		commit("a" + strconv.Itoa(i))
+	}
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	state, err := LoadGitState(statePath)
+	require.NoError(t, err)
+	files, state := scan(state)
+	assert.ElementsMatch(t, []string{"a0", "a1", "a2", "a3", "a4"}, files)
+	require.NoError(t, state.Save(statePath))
+	state, err = LoadGitState(statePath)
+	require.NoError(t, err)
+	assert.Contains(t, state.Refs, "refs/heads/main")
+
+	// only the commits of the new and updated refs are scanned
+	git("checkout", "-q", "-b", "feature")
+	commit("b0")
+	git("checkout", "-q", "main")
+	commit("a5")
+	files, state = scan(state)
+	assert.ElementsMatch(t, []string{"b0", "a5"}, files)
+
+	files, _ = scan(state)
+	assert.Empty(t, files)
+
+	// the commits replaced by a force push and pruned are excluded by their
+	// ancestors, the commits of a deleted ref are not scanned again
+	git("branch", "-q", "-D", "feature")
+	git("reset", "-q", "--hard", "HEAD~2")
+	commit("c0")
+	git("reflog", "expire", "--expire=now", "--all")
+	git("gc", "-q", "--prune=now")
+	files, _ = scan(state)
+	assert.ElementsMatch(t, []string{"c0"}, files)
+}
+
+func TestNewGitLogCmdSinceManyRefs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("creates a repository with many refs")
+	}
+	repo := t.TempDir()
+	git := func(stdin string, args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo,
+			"-c", "user.name=gitleaks", "-c", "user.email=gitleaks@example.com"}, args...)...)
+		cmd.Stdin = strings.NewReader(stdin)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("", "init", "-q", "-b", "main")
+
+	// a ref on each of many commits, the scan after the first one lists
+	// twice as many commits as the arguments of a command can hold
+	const refs = 25000
+	var stream strings.Builder
+	for i := 1; i <= refs; i++ {
+		msg := "commit " + strconv.Itoa(i)
+		fmt.Fprintf(&stream, "reset refs/heads/tmp\ncommit refs/heads/tmp\nmark :%d\n"+
+			"committer gitleaks <gitleaks@example.com> 1700000000 +0000\ndata %d\n%s\n", i, len(msg), msg)
+	}
+	marks := filepath.Join(t.TempDir(), "marks")
+	git(stream.String(), "fast-import", "--quiet", "--export-marks="+marks)
+	data, err := os.ReadFile(marks)
+	require.NoError(t, err)
+	var packedRefs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		mark, commit, _ := strings.Cut(line, " ")
+		packedRefs = append(packedRefs, commit+" refs/heads/b"+strings.TrimPrefix(mark, ":"))
+	}
+	sort.Slice(packedRefs, func(i, j int) bool {
+		return packedRefs[i][41:] < packedRefs[j][41:]
+	})
+	git("", "update-ref", "-d", "refs/heads/tmp")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".git", "packed-refs"),
+		[]byte("# pack-refs with: peeled fully-peeled sorted \n"+strings.Join(packedRefs, "\n")+"\n"), 0o600))
+
+	scan := func(state GitState) ([]string, GitState) {
+		gitCmd, next, err := NewGitLogCmdSince(repo, state)
+		require.NoError(t, err)
+		var files []string
+		for f := range gitCmd.DiffFilesCh() {
+			files = append(files, f.NewName)
+		}
+		for range gitCmd.ErrCh() {
+		}
+		require.NoError(t, gitCmd.Wait())
+		return files, next
+	}
+	files, state := scan(GitState{})
+	assert.Empty(t, files)
+	assert.Len(t, state.Refs, refs)
+
+	git("", "checkout", "-q", "b1")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "a0"), []byte("a0\n"), 0o600))
+	git("", "add", "a0")
+	git("", "commit", "-q", "-m", "a0")
+	files, _ = scan(state)
+	assert.Equal(t, []string{"a0"}, files)
+}
@@ -0,0 +1,327 @@
+// Package verify checks whether secrets are active by sending the HTTP
+// request described by the `[rules.verify]` table of their rule.
+package verify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+const (
+	// BaseURLEnv overrides the scheme, host and base path of every
+	// verification request, ex: to send them to a local test server.
+	BaseURLEnv = "GITLEAKS_VERIFY_BASE_URL"
+
+	defaultTimeout = 10 * time.Second
+
+	// maxBodySize is the size of the response body matched against
+	// SuccessBodyRegex
+	maxBodySize = 1 << 20
+)
+
+// Options configure a Verifier.
+type Options struct {
+	// Concurrency is the maximum number of requests in flight.
+	Concurrency int
+
+	// RateLimit is the maximum number of requests per second sent to a
+	// single host, 0 disables rate limiting.
+	RateLimit float64
+
+	// Timeout of a single request, defaults to 10 seconds.
+	Timeout time.Duration
+
+	// BaseURL replaces the scheme and host of every request.
+	BaseURL string
+
+	// Client is used to send the requests, defaults to a client using
+	// Timeout.
+	Client *http.Client
+}
+
+// Verifier sends verification requests. Responses are cached so a secret
+// found more than once is only verified once. A Verifier is safe for
+// concurrent use.
+type Verifier struct {
+	client  *http.Client
+	baseURL *url.URL
+	sem     chan struct{}
+
+	// interval is the minimum time between two requests to a host
+	interval time.Duration
+
+	mu        sync.Mutex
+	nextSlot  map[string]time.Time
+	cache     map[string]*result
+	templates map[string]*template.Template
+}
+
+// result is a cached verification result, done is closed once status is set
+type result struct {
+	done   chan struct{}
+	status report.VerificationStatus
+}
+
+// templateData is passed to the templates of a verify table
+type templateData struct {
+	Secret   string
+	Match    string
+	Groups   map[string]string
+	Required map[string]string
+}
+
+// New returns a verifier configured with opts.
+func New(opts Options) (*Verifier, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	v := &Verifier{
+		client:    opts.Client,
+		sem:       make(chan struct{}, opts.Concurrency),
+		nextSlot:  make(map[string]time.Time),
+		cache:     make(map[string]*result),
+		templates: make(map[string]*template.Template),
+	}
+	if v.client == nil {
+		v.client = &http.Client{Timeout: opts.Timeout}
+	}
+	if opts.RateLimit > 0 {
+		v.interval = time.Duration(float64(time.Second) / opts.RateLimit)
+	}
+	if opts.BaseURL != "" {
+		u, err := url.Parse(opts.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification base url: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid verification base url %s: scheme and host are required", opts.BaseURL)
+		}
+		v.baseURL = u
+	}
+	return v, nil
+}
+
+// Verify checks whether the secret of a finding of rule is active and sets
+// the Verification status of the finding. Findings of rules without a verify
+// table are left untouched.
+func (v *Verifier) Verify(ctx context.Context, rule config.Rule, finding *report.Finding) {
+	if rule.Verify == nil {
+		return
+	}
+	req, key, err := v.newRequest(ctx, rule, finding)
+	if err != nil {
+		log.Debug().Err(err).Msgf("unable to build verification request for %s", rule.RuleID)
+		finding.Verification = report.VerificationError
+		return
+	}
+
+	v.mu.Lock()
+	r, ok := v.cache[key]
+	if !ok {
+		r = &result{done: make(chan struct{})}
+		v.cache[key] = r
+	}
+	v.mu.Unlock()
+
+	if ok {
+		select {
+		case <-r.done:
+			finding.Verification = r.status
+		case <-ctx.Done():
+			finding.Verification = report.VerificationError
+		}
+		return
+	}
+	r.status = v.send(ctx, rule.Verify, req)
+	if ctx.Err() != nil {
+		// the secret was not verified, a later scan verifies it again
+		v.mu.Lock()
+		delete(v.cache, key)
+		v.mu.Unlock()
+	}
+	close(r.done)
+	finding.Verification = r.status
+}
+
+// send sends the request, respecting the concurrency and rate limits, and
+// matches the response.
+func (v *Verifier) send(ctx context.Context, verify *config.Verify, req *http.Request) report.VerificationStatus {
+	select {
+	case v.sem <- struct{}{}:
+		defer func() { <-v.sem }()
+	case <-ctx.Done():
+		return report.VerificationError
+	}
+	if err := v.wait(ctx, req.URL.Host); err != nil {
+		return report.VerificationError
+	}
+
+	log.Debug().Msgf("verifying secret with %s %s", req.Method, req.URL.Redacted())
+	resp, err := v.client.Do(req)
+	if err != nil {
+		log.Debug().Err(err).Msg("verification request failed")
+		return report.VerificationError
+	}
+	defer resp.Body.Close()
+
+	// rate limited or the service is unavailable, the secret may be active
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return report.VerificationError
+	}
+
+	statusMatched := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if len(verify.SuccessStatusCodes) > 0 {
+		statusMatched = false
+		for _, code := range verify.SuccessStatusCodes {
+			if resp.StatusCode == code {
+				statusMatched = true
+				break
+			}
+		}
+	}
+	if !statusMatched {
+		return report.Unverified
+	}
+	if verify.SuccessBodyRegex != nil {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+		if err != nil {
+			return report.VerificationError
+		}
+		if !verify.SuccessBodyRegex.Match(body) {
+			return report.Unverified
+		}
+	}
+	return report.Verified
+}
+
+// wait blocks until a request can be sent to host without exceeding the rate
+// limit.
+func (v *Verifier) wait(ctx context.Context, host string) error {
+	if v.interval == 0 {
+		return nil
+	}
+	v.mu.Lock()
+	now := time.Now()
+	slot := v.nextSlot[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	v.nextSlot[host] = slot.Add(v.interval)
+	v.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(slot))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newRequest renders the verify table of rule for the finding. The returned
+// key identifies the request in the cache.
+func (v *Verifier) newRequest(ctx context.Context, rule config.Rule, finding *report.Finding) (*http.Request, string, error) {
+	data := templateData{
+		Secret:   finding.Secret,
+		Match:    finding.Match,
+		Groups:   make(map[string]string),
+		Required: make(map[string]string),
+	}
+	if rule.Regex != nil {
+		groups := rule.Regex.FindStringSubmatch(finding.Match)
+		for i, name := range rule.Regex.SubexpNames() {
+			if name != "" && i < len(groups) {
+				data.Groups[name] = groups[i]
+			}
+		}
+	}
+	for _, m := range finding.AuxiliaryMatches {
+		if m.RuleID != "" {
+			data.Required[m.RuleID] = m.Secret
+		}
+	}
+
+	rawURL, err := v.render(rule.RuleID+".url", rule.Verify.URL, data)
+	if err != nil {
+		return nil, "", err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+	if v.baseURL != nil {
+		u.Scheme = v.baseURL.Scheme
+		u.Host = v.baseURL.Host
+		u.Path = strings.TrimSuffix(v.baseURL.Path, "/") + u.Path
+		u.RawPath = ""
+	}
+	body, err := v.render(rule.RuleID+".body", rule.Verify.Body, data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, rule.Verify.Method, u.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	names := make([]string, 0, len(rule.Verify.Headers))
+	for name := range rule.Verify.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var key strings.Builder
+	fmt.Fprintf(&key, "%s %s\n", req.Method, req.URL)
+	for _, name := range names {
+		value, err := v.render(rule.RuleID+".headers."+name, rule.Verify.Headers[name], data)
+		if err != nil {
+			return nil, "", err
+		}
+		req.Header.Set(name, value)
+		fmt.Fprintf(&key, "%s: %s\n", http.CanonicalHeaderKey(name), value)
+	}
+	key.WriteString(body)
+	return req, key.String(), nil
+}
+
+// render executes the template text, templates are parsed once and cached.
+func (v *Verifier) render(name, text string, data templateData) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	v.mu.Lock()
+	t, ok := v.templates[text]
+	if !ok {
+		var err error
+		t, err = template.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			v.mu.Unlock()
+			return "", err
+		}
+		v.templates[text] = t
+	}
+	v.mu.Unlock()
+
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
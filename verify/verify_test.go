@@ -0,0 +1,106 @@
+package verify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zricethezav/gitleaks/v8/config"
+	"github.com/zricethezav/gitleaks/v8/report"
+)
+
+func TestVerify(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch {
+		case r.URL.Path != "/api/user":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Header.Get("Authorization") == "token active":
+			w.Write([]byte(`{"login": "octocat"}`))
+		case r.Header.Get("Authorization") == "token suspended":
+			w.Write([]byte(`{"suspended": true}`))
+		case r.Header.Get("Authorization") == "token flaky":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	rule := config.Rule{
+		RuleID: "test-token",
+		Regex:  regexp.MustCompile(`token_(?P<token>[a-z]+)`),
+		Verify: &config.Verify{
+			Method: "GET",
+			// the host is replaced by the base url
+			URL:              "https://api.example.com/user",
+			Headers:          map[string]string{"authorization": "token {{ .Groups.token }}"},
+			SuccessBodyRegex: regexp.MustCompile(`"login"`),
+		},
+	}
+
+	tests := []struct {
+		match    string
+		expected report.VerificationStatus
+	}{
+		{
+			match:    "token_active",
+			expected: report.Verified,
+		},
+		{
+			match:    "token_revoked",
+			expected: report.Unverified,
+		},
+		{
+			// the response body does not match
+			match:    "token_suspended",
+			expected: report.Unverified,
+		},
+		{
+			match:    "token_flaky",
+			expected: report.VerificationError,
+		},
+		{
+			// cached, no request is sent
+			match:    "token_active",
+			expected: report.Verified,
+		},
+	}
+
+	verifier, err := New(Options{Concurrency: 2, BaseURL: server.URL + "/api"})
+	require.NoError(t, err)
+	for _, tt := range tests {
+		finding := report.Finding{RuleID: rule.RuleID, Match: tt.match, Secret: tt.match}
+		verifier.Verify(context.Background(), rule, &finding)
+		assert.Equal(t, tt.expected, finding.Verification, tt.match)
+	}
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+
+	// findings of rules without a verify table are not verified
+	finding := report.Finding{RuleID: "other", Secret: "token_active"}
+	verifier.Verify(context.Background(), config.Rule{RuleID: "other"}, &finding)
+	assert.Empty(t, finding.Verification)
+
+	// the results of canceled verifications are not cached
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	finding = report.Finding{RuleID: rule.RuleID, Match: "token_revoked", Secret: "token_revoked"}
+	verifier, err = New(Options{BaseURL: server.URL + "/api"})
+	require.NoError(t, err)
+	verifier.Verify(ctx, rule, &finding)
+	assert.Equal(t, report.VerificationError, finding.Verification)
+	verifier.Verify(context.Background(), rule, &finding)
+	assert.Equal(t, report.Unverified, finding.Verification)
+}
+
+func TestNewInvalidBaseURL(t *testing.T) {
+	_, err := New(Options{BaseURL: "localhost:8080"})
+	assert.Error(t, err)
+}